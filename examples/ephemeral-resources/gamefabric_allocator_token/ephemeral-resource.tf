@@ -0,0 +1,17 @@
+# Get the active tokens of an allocator without storing them in the state.
+ephemeral "gamefabric_allocator_token" "this" {
+  name = "my-allocator"
+}
+
+# Pass the allocation endpoint to a game backend through a write-only attribute:
+
+resource "gamefabric_secret" "allocator" {
+  name        = "allocator"
+  environment = "prod"
+
+  data_wo = {
+    url   = ephemeral.gamefabric_allocator_token.this.allocation_url
+    token = ephemeral.gamefabric_allocator_token.this.allocation_token
+  }
+  data_wo_version = 1
+}
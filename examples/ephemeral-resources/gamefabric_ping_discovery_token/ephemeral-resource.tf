@@ -0,0 +1,17 @@
+# Get the active token of a ping discovery without storing it in the state.
+ephemeral "gamefabric_ping_discovery_token" "this" {
+  name = "my-ping-discovery"
+}
+
+# Pass the ping discovery endpoint to a game backend through a write-only attribute:
+
+resource "gamefabric_secret" "ping_discovery" {
+  name        = "ping-discovery"
+  environment = "prod"
+
+  data_wo = {
+    url   = ephemeral.gamefabric_ping_discovery_token.this.url
+    token = ephemeral.gamefabric_ping_discovery_token.this.token
+  }
+  data_wo_version = 1
+}
@@ -0,0 +1,28 @@
+variable "rotate_password" {
+  type    = bool
+  default = false
+}
+
+resource "gamefabric_service_account" "example" {
+  name = "my-service-account"
+}
+
+# Reset the password without storing it in the state.
+# The password is only reset when `reset` is true, for example with
+# `terraform apply -var rotate_password=true`. The previous password then stops working.
+ephemeral "gamefabric_service_account_password" "example" {
+  service_account = gamefabric_service_account.example.name
+  reset           = var.rotate_password
+}
+
+resource "gamefabric_secret" "credentials" {
+  name        = "service-account"
+  environment = "prod"
+
+  data_wo = {
+    username = gamefabric_service_account.example.name
+    password = ephemeral.gamefabric_service_account_password.example.password
+  }
+  # Bump the version together with rotate_password to store the new password.
+  data_wo_version = 1
+}
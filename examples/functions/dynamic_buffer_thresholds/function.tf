@@ -0,0 +1,11 @@
+locals {
+  thresholds = provider::gamefabric::dynamic_buffer_thresholds(60)
+}
+
+output "dynamic_max_buffer_threshold" {
+  value = local.thresholds.dynamic_max_buffer_threshold # 129
+}
+
+output "dynamic_min_buffer_threshold" {
+  value = local.thresholds.dynamic_min_buffer_threshold # 39
+}
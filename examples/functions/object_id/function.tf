@@ -0,0 +1,4 @@
+import {
+  id = provider::gamefabric::object_id("prod", "my-armada") # "prod/my-armada"
+  to = gamefabric_armada.this
+}
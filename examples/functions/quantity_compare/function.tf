@@ -0,0 +1,9 @@
+variable "memory" {
+  type    = string
+  default = "512Mi"
+
+  validation {
+    condition     = provider::gamefabric::quantity_compare(var.memory, "2Gi") <= 0
+    error_message = "The memory must not exceed 2Gi."
+  }
+}
@@ -0,0 +1,7 @@
+locals {
+  armada = provider::gamefabric::split_object_id(gamefabric_armada.this.id)
+}
+
+output "armada_environment" {
+  value = local.armada.environment
+}
@@ -0,0 +1,11 @@
+list "gamefabric_armada" "all" {
+  provider = gamefabric
+
+  config {
+    environment = "prod"
+
+    label_filter = {
+      team = "backend"
+    }
+  }
+}
@@ -0,0 +1,9 @@
+list "gamefabric_branch" "all" {
+  provider = gamefabric
+
+  config {
+    label_filter = {
+      team = "backend"
+    }
+  }
+}
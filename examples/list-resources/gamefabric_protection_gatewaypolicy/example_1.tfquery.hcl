@@ -0,0 +1,9 @@
+list "gamefabric_protection_gatewaypolicy" "all" {
+  provider = gamefabric
+
+  config {
+    label_filter = {
+      team = "backend"
+    }
+  }
+}
@@ -0,0 +1,11 @@
+list "gamefabric_region" "all" {
+  provider = gamefabric
+
+  config {
+    environment = "prod"
+
+    label_filter = {
+      team = "backend"
+    }
+  }
+}
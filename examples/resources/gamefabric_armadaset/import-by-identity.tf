@@ -0,0 +1,7 @@
+import {
+  identity = {
+    environment = "{{ environment }}"
+    name        = "{{ name }}"
+  }
+  to = gamefabric_armadaset.europe
+}
@@ -0,0 +1,6 @@
+import {
+  identity = {
+    name = "{{name}}"
+  }
+  to = gamefabric_cloudbudget.my_budget
+}
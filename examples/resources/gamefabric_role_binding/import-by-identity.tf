@@ -0,0 +1,6 @@
+import {
+  identity = {
+    name = "{{ role }}"
+  }
+  to = gamefabric_role_binding.developer_binding
+}
@@ -0,0 +1,6 @@
+import {
+  identity = {
+    name = "{{ name }}"
+  }
+  to = gamefabric_service_account.ci_workflow
+}
@@ -56,6 +56,14 @@ func EmptyIfNil[T any](v []T) []T {
 	return v
 }
 
+// LastOrNull returns the last item of s, or null if s is empty.
+func LastOrNull(s []string) types.String {
+	if len(s) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(s[len(s)-1])
+}
+
 // FromIntOrString converts an IntOrString to a Terraform String type.
 func FromIntOrString(val *intstr.IntOrString) types.String {
 	if val == nil {
@@ -113,3 +121,38 @@ func MapWithBool(mp map[string]types.String, key string, val types.Bool) map[str
 	}
 	return res
 }
+
+// MergeMaps merges the given maps into a new map. Values of later maps take precedence.
+func MergeMaps[V any](maps ...map[string]V) map[string]V {
+	var res map[string]V
+	for _, m := range maps {
+		for k, v := range m {
+			if res == nil {
+				res = make(map[string]V)
+			}
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// WithoutDefaults returns the items of m that were configured or are not
+// provided with the same value by defaults.
+//
+// This keeps provider-wide default labels and annotations out of the
+// user-facing attribute, while still surfacing values changed outside of Terraform.
+func WithoutDefaults(m, configured map[string]types.String, defaults types.Map) map[string]types.String {
+	var res map[string]types.String
+	for k, v := range m {
+		if _, ok := configured[k]; !ok {
+			if def, ok := defaults.Elements()[k]; ok && def.Equal(v) {
+				continue
+			}
+		}
+		if res == nil {
+			res = make(map[string]types.String, len(m))
+		}
+		res[k] = v
+	}
+	return res
+}
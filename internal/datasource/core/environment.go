@@ -9,6 +9,7 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -25,6 +26,7 @@ var (
 
 type environment struct {
 	clientSet clientset.Interface
+	lists     *listcache.Cache
 }
 
 // NewEnvironment creates a new environment data source.
@@ -89,6 +91,7 @@ func (r *environment) Configure(_ context.Context, req datasource.ConfigureReque
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
 }
 
 func (r *environment) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -113,7 +116,13 @@ func (r *environment) Read(ctx context.Context, req datasource.ReadRequest, resp
 			return
 		}
 	case conv.IsKnown(config.DisplayName):
-		list, err := r.clientSet.CoreV1().Environments().List(ctx, metav1.ListOptions{})
+		items, err := listcache.Get(ctx, r.lists, listcache.Key{Kind: "Environment"}, func(ctx context.Context) ([]corev1.Environment, error) {
+			list, err := r.clientSet.CoreV1().Environments().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Getting Environment",
@@ -121,7 +130,7 @@ func (r *environment) Read(ctx context.Context, req datasource.ReadRequest, resp
 			)
 			return
 		}
-		for _, item := range list.Items {
+		for _, item := range items {
 			if item.Spec.DisplayName != config.DisplayName.ValueString() {
 				continue
 			}
@@ -11,6 +11,7 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,6 +24,7 @@ var (
 
 type environments struct {
 	clientSet clientset.Interface
+	lists     *listcache.Cache
 }
 
 // NewEnvironments returns a new instance of the environments data source.
@@ -94,6 +96,7 @@ func (r *environments) Configure(_ context.Context, req datasource.ConfigureRequ
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
 }
 
 func (r *environments) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -103,8 +106,12 @@ func (r *environments) Read(ctx context.Context, req datasource.ReadRequest, res
 		return
 	}
 
-	list, err := r.clientSet.CoreV1().Environments().List(ctx, metav1.ListOptions{
-		LabelSelector: conv.ForEachMapItem(config.LabelFilter, func(item types.String) string { return item.ValueString() }),
+	items, err := listcache.Get(ctx, r.lists, listcache.Key{Kind: "Environment"}, func(ctx context.Context) ([]corev1.Environment, error) {
+		list, err := r.clientSet.CoreV1().Environments().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -113,11 +120,15 @@ func (r *environments) Read(ctx context.Context, req datasource.ReadRequest, res
 		)
 		return
 	}
-	slices.SortFunc(list.Items, func(a, b corev1.Environment) int {
+	selector := conv.ForEachMapItem(config.LabelFilter, func(item types.String) string { return item.ValueString() })
+	items = slices.DeleteFunc(items, func(item corev1.Environment) bool {
+		return !listcache.MatchLabels(item.Labels, selector)
+	})
+	slices.SortFunc(items, func(a, b corev1.Environment) int {
 		return strings.Compare(a.Name, b.Name)
 	})
 
-	state := newEnvironmentsModel(list.Items)
+	state := newEnvironmentsModel(items)
 	state.LabelFilter = config.LabelFilter
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -8,9 +8,11 @@ import (
 	"strings"
 
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -24,6 +26,7 @@ var (
 
 type locations struct {
 	clientSet clientset.Interface
+	lists     *listcache.Cache
 }
 
 // NewLocations returns a new instance of the locations data source.
@@ -95,6 +98,7 @@ func (r *locations) Configure(_ context.Context, req datasource.ConfigureRequest
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
 }
 
 //nolint:cyclop // Splitting this would not make it much simpler.
@@ -127,7 +131,13 @@ func (r *locations) Read(ctx context.Context, req datasource.ReadRequest, resp *
 		hasNameRegex = true
 	}
 
-	list, err := r.clientSet.CoreV1().Locations().List(ctx, metav1.ListOptions{})
+	items, err := listcache.Get(ctx, r.lists, listcache.Key{Kind: "Location"}, func(ctx context.Context) ([]corev1.Location, error) {
+		list, err := r.clientSet.CoreV1().Locations().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Getting Locations",
@@ -146,8 +156,8 @@ func (r *locations) Read(ctx context.Context, req datasource.ReadRequest, resp *
 		return
 	}
 
-	locs := make([]string, 0, len(list.Items))
-	for _, loc := range list.Items {
+	locs := make([]string, 0, len(items))
+	for _, loc := range items {
 		annos := loc.GetAnnotations()
 		if annos == nil {
 			annos = map[string]string{}
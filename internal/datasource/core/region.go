@@ -10,6 +10,7 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -25,6 +26,7 @@ var (
 
 type region struct {
 	clientSet clientset.Interface
+	lists     *listcache.Cache
 }
 
 // NewRegion creates a new region data source.
@@ -105,6 +107,7 @@ func (r *region) Configure(_ context.Context, req datasource.ConfigureRequest, r
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
 }
 
 func (r *region) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -136,7 +139,13 @@ func (r *region) Read(ctx context.Context, req datasource.ReadRequest, resp *dat
 			return
 		}
 	case conv.IsKnown(config.DisplayName):
-		list, err := r.clientSet.CoreV1().Regions(config.Environment.ValueString()).List(ctx, metav1.ListOptions{})
+		items, err := listcache.Get(ctx, r.lists, listcache.Key{Kind: "Region", Environment: config.Environment.ValueString()}, func(ctx context.Context) ([]corev1.Region, error) {
+			list, err := r.clientSet.CoreV1().Regions(config.Environment.ValueString()).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Getting Region",
@@ -144,7 +153,7 @@ func (r *region) Read(ctx context.Context, req datasource.ReadRequest, resp *dat
 			)
 			return
 		}
-		for _, item := range list.Items {
+		for _, item := range items {
 			if item.Spec.DisplayName != config.DisplayName.ValueString() {
 				continue
 			}
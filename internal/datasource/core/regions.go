@@ -11,6 +11,7 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -25,6 +26,7 @@ var (
 
 type regions struct {
 	clientSet clientset.Interface
+	lists     *listcache.Cache
 }
 
 // NewRegions creates a new regions data source.
@@ -118,6 +120,7 @@ func (r *regions) Configure(_ context.Context, req datasource.ConfigureRequest,
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
 }
 
 func (r *regions) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -127,8 +130,12 @@ func (r *regions) Read(ctx context.Context, req datasource.ReadRequest, resp *da
 		return
 	}
 
-	list, err := r.clientSet.CoreV1().Regions(config.Environment.ValueString()).List(ctx, metav1.ListOptions{
-		LabelSelector: conv.ForEachMapItem(config.LabelFilter, func(item types.String) string { return item.ValueString() }),
+	items, err := listcache.Get(ctx, r.lists, listcache.Key{Kind: "Region", Environment: config.Environment.ValueString()}, func(ctx context.Context) ([]corev1.Region, error) {
+		list, err := r.clientSet.CoreV1().Regions(config.Environment.ValueString()).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -137,11 +144,15 @@ func (r *regions) Read(ctx context.Context, req datasource.ReadRequest, resp *da
 		)
 		return
 	}
-	slices.SortFunc(list.Items, func(a, b corev1.Region) int {
+	selector := conv.ForEachMapItem(config.LabelFilter, func(item types.String) string { return item.ValueString() })
+	items = slices.DeleteFunc(items, func(item corev1.Region) bool {
+		return !listcache.MatchLabels(item.Labels, selector)
+	})
+	slices.SortFunc(items, func(a, b corev1.Region) int {
 		return strings.Compare(a.Name, b.Name)
 	})
 
-	state := newRegionsModel(list.Items)
+	state := newRegionsModel(items)
 	state.Environment = config.Environment
 	state.LabelFilter = config.LabelFilter
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
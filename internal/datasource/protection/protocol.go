@@ -10,6 +10,7 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -25,6 +26,7 @@ var (
 
 type protocol struct {
 	clientSet clientset.Interface
+	lists     *listcache.Cache
 }
 
 // NewProtocol creates a new protocol data source.
@@ -88,6 +90,7 @@ func (r *protocol) Configure(_ context.Context, req datasource.ConfigureRequest,
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
 }
 
 func (r *protocol) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -119,7 +122,13 @@ func (r *protocol) Read(ctx context.Context, req datasource.ReadRequest, resp *d
 			return
 		}
 	case conv.IsKnown(config.DisplayName):
-		list, err := r.clientSet.ProtectionV1().Protocols().List(ctx, metav1.ListOptions{})
+		items, err := listcache.Get(ctx, r.lists, listcache.Key{Kind: "Protocol"}, func(ctx context.Context) ([]protectionv1.Protocol, error) {
+			list, err := r.clientSet.ProtectionV1().Protocols().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Getting Protocol",
@@ -127,7 +136,7 @@ func (r *protocol) Read(ctx context.Context, req datasource.ReadRequest, resp *d
 			)
 			return
 		}
-		for _, item := range list.Items {
+		for _, item := range items {
 			if item.Spec.DisplayName != config.DisplayName.ValueString() {
 				continue
 			}
@@ -10,6 +10,7 @@ import (
 	protectionv1 "github.com/gamefabric/gf-core/pkg/api/protection/v1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 )
@@ -21,6 +22,7 @@ var (
 
 type protocols struct {
 	clientSet clientset.Interface
+	lists     *listcache.Cache
 }
 
 // NewProtocols returns a new instance of the protocols data source.
@@ -85,6 +87,7 @@ func (r *protocols) Configure(_ context.Context, req datasource.ConfigureRequest
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
 }
 
 func (r *protocols) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -94,7 +97,13 @@ func (r *protocols) Read(ctx context.Context, req datasource.ReadRequest, resp *
 		return
 	}
 
-	list, err := r.clientSet.ProtectionV1().Protocols().List(ctx, metav1.ListOptions{})
+	items, err := listcache.Get(ctx, r.lists, listcache.Key{Kind: "Protocol"}, func(ctx context.Context) ([]protectionv1.Protocol, error) {
+		list, err := r.clientSet.ProtectionV1().Protocols().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Getting Protocols",
@@ -102,10 +111,10 @@ func (r *protocols) Read(ctx context.Context, req datasource.ReadRequest, resp *
 		)
 		return
 	}
-	slices.SortFunc(list.Items, func(a, b protectionv1.Protocol) int {
+	slices.SortFunc(items, func(a, b protectionv1.Protocol) int {
 		return strings.Compare(a.Name, b.Name)
 	})
 
-	state := newProtocolsModel(list.Items)
+	state := newProtocolsModel(items)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
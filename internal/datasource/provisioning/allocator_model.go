@@ -26,18 +26,10 @@ func newAllocatorModel(obj *provisioningv1beta1.Allocator) allocatorModel {
 		RateLimitQPS:          conv.OptionalFunc(obj.Spec.RateLimit.QPS, func(v int) types.Int64 { return types.Int64Value(int64(v)) }, types.Int64Null),
 		RateLimitBurst:        conv.OptionalFunc(obj.Spec.RateLimit.Burst, func(v int) types.Int64 { return types.Int64Value(int64(v)) }, types.Int64Null),
 		AllocationURL:         conv.OptionalFunc(obj.Status.Allocation.URL, types.StringValue, types.StringNull),
-		AllocationActiveToken: activeToken(obj.Status.Allocation.Tokens),
+		AllocationActiveToken: conv.LastOrNull(obj.Status.Allocation.Tokens),
 		AllocationTokens:      conv.EmptyIfNil(conv.ForEachSliceItem(obj.Status.Allocation.Tokens, types.StringValue)),
 		RegistryURL:           conv.OptionalFunc(obj.Status.Registration.URL, types.StringValue, types.StringNull),
-		RegistryActiveToken:   activeToken(obj.Status.Registration.Tokens),
+		RegistryActiveToken:   conv.LastOrNull(obj.Status.Registration.Tokens),
 		RegistryTokens:        conv.EmptyIfNil(conv.ForEachSliceItem(obj.Status.Registration.Tokens, types.StringValue)),
 	}
 }
-
-// activeToken returns the last token in the list, or null if the list is empty.
-func activeToken(tokens []string) types.String {
-	if len(tokens) == 0 {
-		return types.StringNull()
-	}
-	return types.StringValue(tokens[len(tokens)-1])
-}
@@ -17,7 +17,7 @@ func newPingDiscoveryModel(obj *provisioningv1beta1.PingDiscovery) pingDiscovery
 	return pingDiscoveryModel{
 		Name:        types.StringValue(obj.Name),
 		URL:         conv.OptionalFunc(obj.Status.URL, types.StringValue, types.StringNull),
-		ActiveToken: activeToken(obj.Status.Tokens),
+		ActiveToken: conv.LastOrNull(obj.Status.Tokens),
 		Tokens:      conv.EmptyIfNil(conv.ForEachSliceItem(obj.Status.Tokens, types.StringValue)),
 	}
 }
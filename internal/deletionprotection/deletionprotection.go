@@ -0,0 +1,40 @@
+// Package deletionprotection provides the deletion_protection attribute of resources
+// whose deletion can not be undone, and the check that guards their deletion.
+package deletionprotection
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Attribute returns the schema of the deletion_protection attribute of a resource of the given kind.
+func Attribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Prevent the " + kind + " from being deleted. While it is set, destroying or replacing the " + kind +
+			" fails until it is set to 'false' and applied. Defaults to 'false'.",
+		MarkdownDescription: "Prevent the " + kind + " from being deleted. While it is set, destroying or replacing the " + kind +
+			" fails until it is set to `false` and applied. Defaults to `false`.",
+		Optional: true,
+	}
+}
+
+// Check returns an error if the deletion of the named object is protected.
+// enabled is the deletion_protection attribute of the current state.
+func Check(kind, name string, enabled types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !enabled.ValueBool() {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion Protection Enabled",
+		fmt.Sprintf("Cannot delete %s %q while deletion_protection is enabled. "+
+			"Set deletion_protection to false and apply the change before deleting it.", kind, name),
+	)
+	return diags
+}
@@ -0,0 +1,54 @@
+package deletionprotection_test
+
+import (
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/deletionprotection"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		enabled types.Bool
+		wantErr bool
+	}{
+		{
+			name:    "unset",
+			enabled: types.BoolNull(),
+		},
+		{
+			name:    "disabled",
+			enabled: types.BoolValue(false),
+		},
+		{
+			name:    "enabled",
+			enabled: types.BoolValue(true),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := deletionprotection.Check("Volume", "test", test.enabled)
+
+			if !test.wantErr {
+				assert.False(t, got.HasError())
+				return
+			}
+			require.Len(t, got, 1)
+			assert.Equal(t, "Deletion Protection Enabled", got[0].Summary())
+			assert.Contains(t, got[0].Detail(), `Volume "test"`)
+			if d, ok := got[0].(interface{ Path() path.Path }); assert.True(t, ok) {
+				assert.Equal(t, path.Root("deletion_protection"), d.Path())
+			}
+		})
+	}
+}
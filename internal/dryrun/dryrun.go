@@ -0,0 +1,161 @@
+// Package dryrun validates planned objects with a server-side dry run, so that
+// admission checks that need live state, such as quotas, referenced objects and
+// immutable fields, are reported during plan instead of apply.
+package dryrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gamefabric/gf-apiclient/rest"
+	"github.com/gamefabric/gf-apiclient/tools/patch"
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/gf-apicore/runtime"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/tfutils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// dryRunAll runs all stages of a request without persisting the result.
+const dryRunAll = "All"
+
+// Client creates and patches objects of a kind.
+type Client[T runtime.Object] interface {
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte, opts metav1.UpdateOptions) (T, error)
+}
+
+// Resource describes the objects of type T of a resource with model M.
+type Resource[T runtime.Object, M any] struct {
+	// Kind is the kind of the objects, for example "Armada".
+	Kind string
+	// Client returns the client of the planned object.
+	Client func(plan M) Client[T]
+	// Name returns the name of the object of m.
+	Name func(m M) string
+	// ToObject returns the object of m.
+	ToObject func(m M) T
+	// Prepare, if set, completes the planned model before it is validated, for
+	// example with write-only values that are only part of the configuration.
+	// The state is nil if the object is created.
+	Prepare func(ctx context.Context, req resource.ModifyPlanRequest, plan, state *M) diag.Diagnostics
+}
+
+// ModifyPlan validates the planned object of r with a server-side dry run if
+// enabled. A new object is created and an existing one patched.
+//
+// Replacements are not validated, as the old object still exists during the dry run,
+// and neither are plans whose configuration is not fully known yet.
+func ModifyPlan[T runtime.Object, M any](ctx context.Context, enabled bool, r Resource[T, M], req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !enabled || len(resp.RequiresReplace) > 0 {
+		return
+	}
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan M
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *M
+	if !req.State.Raw.IsNull() {
+		state = new(M)
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if r.Prepare != nil {
+		resp.Diagnostics.Append(r.Prepare(ctx, req, &plan, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := r.Client(plan)
+	if state == nil {
+		resp.Diagnostics.Append(Create(ctx, client, r.Kind, r.ToObject(plan), resp.Plan)...)
+		return
+	}
+	resp.Diagnostics.Append(Patch(ctx, client, r.Kind, r.Name(*state), r.ToObject(*state), r.ToObject(plan), resp.Plan)...)
+}
+
+// Create sends obj to the API as a dry-run create.
+// A rejection is returned as errors on the attributes of plan it names.
+func Create[T runtime.Object](ctx context.Context, client Client[T], kind string, obj T, plan tfsdk.Plan) diag.Diagnostics {
+	_, err := client.Create(ctx, obj, metav1.CreateOptions{DryRun: []string{dryRunAll}})
+	if err != nil {
+		return diagnostics(err, kind, plan)
+	}
+	return nil
+}
+
+// Patch sends the changes from oldObj to newObj to the API as a dry-run patch.
+// A rejection is returned as errors on the attributes of plan it names.
+func Patch[T runtime.Object](ctx context.Context, client Client[T], kind, name string, oldObj, newObj T, plan tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pb, err := patch.Create(oldObj, newObj)
+	if err != nil {
+		diags.AddError(
+			"Error Creating "+kind+" Patch",
+			fmt.Sprintf("Could not create patch for %s: %v", kind, err),
+		)
+		return diags
+	}
+	if string(pb) == "{}" {
+		// Nothing changes.
+		return nil
+	}
+
+	if _, err = client.Patch(ctx, name, rest.MergePatchType, pb, metav1.UpdateOptions{DryRun: []string{dryRunAll}}); err != nil {
+		return diagnostics(err, kind, plan)
+	}
+	return nil
+}
+
+func diagnostics(err error, kind string, plan tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !isRejection(err) {
+		// The API could not be asked. The apply reports the error if it persists.
+		diags.AddWarning(
+			"Could Not Validate "+kind,
+			fmt.Sprintf("Could not validate the planned %s with a dry run: %v. "+
+				"Errors may only be reported during apply.", kind, err),
+		)
+		return diags
+	}
+
+	summary := "Invalid " + kind
+	detail := fmt.Sprintf("The API rejected the planned %s: %v", kind, err)
+
+	s, ok := plan.Schema.(rschema.Schema)
+	if !ok {
+		diags.AddError(summary, detail)
+		return diags
+	}
+	paths := attributePaths(err.Error(), fieldPaths(s))
+	if len(paths) == 0 {
+		diags.AddError(summary, detail)
+		return diags
+	}
+	for _, p := range paths {
+		diags.AddAttributeError(p, summary, detail)
+	}
+	return diags
+}
+
+// isRejection reports whether err rejects the planned object. Other errors, such
+// as missing permissions to run the dry run, mean that the object could not be validated.
+func isRejection(err error) bool {
+	return apierrors.IsInvalid(err) ||
+		apierrors.IsBadRequest(err) ||
+		apierrors.IsConflict(err)
+}
@@ -0,0 +1,211 @@
+package dryrun_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gamefabric/gf-apiclient/rest"
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/fake"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dryrun"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyPlan_Create(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New()
+	require.NoError(t, err)
+	client := &testClient{Client: cs.CoreV1().ConfigFiles("dflt")}
+
+	resp := testModifyPlan(t, true, client, nil, testValue("test", "data"))
+
+	require.Empty(t, resp.Diagnostics)
+	assert.Equal(t, 1, client.calls)
+
+	_, err = cs.CoreV1().ConfigFiles("dflt").Get(t.Context(), "test", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "dry run created the object")
+}
+
+func TestModifyPlan_Patch(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New(&corev1.ConfigFile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Environment: "dflt"},
+		Data:       "old",
+	})
+	require.NoError(t, err)
+	client := &testClient{Client: cs.CoreV1().ConfigFiles("dflt")}
+
+	resp := testModifyPlan(t, true, client, testValue("test", "old"), testValue("test", "new"))
+
+	require.Empty(t, resp.Diagnostics)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestModifyPlan_SkipsUnchangedPatch(t *testing.T) {
+	t.Parallel()
+
+	client := &testClient{err: errors.New("unexpected call")}
+
+	resp := testModifyPlan(t, true, client, testValue("test", "data"), testValue("test", "data"))
+
+	assert.Empty(t, resp.Diagnostics)
+	assert.Zero(t, client.calls)
+}
+
+func TestModifyPlan_SkipsWhenDisabled(t *testing.T) {
+	t.Parallel()
+
+	client := &testClient{err: errors.New("unexpected call")}
+
+	resp := testModifyPlan(t, false, client, nil, testValue("test", "data"))
+
+	assert.Empty(t, resp.Diagnostics)
+	assert.Zero(t, client.calls)
+}
+
+func TestModifyPlan_Rejection(t *testing.T) {
+	t.Parallel()
+
+	client := &testClient{err: apierrors.NewBadRequest(`ConfigFile "test" is invalid: data: Too long`)}
+
+	resp := testModifyPlan(t, true, client, nil, testValue("test", "data"))
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, diag.SeverityError, resp.Diagnostics[0].Severity())
+	assert.Equal(t, "Invalid ConfigFile", resp.Diagnostics[0].Summary())
+	require.Implements(t, (*diag.DiagnosticWithPath)(nil), resp.Diagnostics[0])
+	assert.Equal(t, path.Root("data"), resp.Diagnostics[0].(diag.DiagnosticWithPath).Path())
+}
+
+func TestModifyPlan_WarnsWhenValidationFails(t *testing.T) {
+	t.Parallel()
+
+	client := &testClient{err: errors.New("connection refused")}
+
+	resp := testModifyPlan(t, true, client, nil, testValue("test", "data"))
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, diag.SeverityWarning, resp.Diagnostics[0].Severity())
+	assert.Equal(t, "Could Not Validate ConfigFile", resp.Diagnostics[0].Summary())
+}
+
+type testModel struct {
+	Name        types.String `tfsdk:"name"`
+	Environment types.String `tfsdk:"environment"`
+	Data        types.String `tfsdk:"data"`
+}
+
+func (m testModel) ToObject() *corev1.ConfigFile {
+	return &corev1.ConfigFile{
+		ObjectMeta: metav1.ObjectMeta{Name: m.Name.ValueString(), Environment: m.Environment.ValueString()},
+		Data:       m.Data.ValueString(),
+	}
+}
+
+var testSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{testValidator("metadata.name")},
+		},
+		"environment": schema.StringAttribute{
+			Required: true,
+		},
+		"data": schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{testValidator("data")},
+		},
+	},
+}
+
+// testClient counts the dry-run calls, failing them with err if set.
+type testClient struct {
+	dryrun.Client[*corev1.ConfigFile]
+
+	err   error
+	calls int
+}
+
+func (c *testClient) Create(ctx context.Context, obj *corev1.ConfigFile, opts metav1.CreateOptions) (*corev1.ConfigFile, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	if len(opts.DryRun) == 0 {
+		return nil, errors.New("not a dry run")
+	}
+	return c.Client.Create(ctx, obj, opts)
+}
+
+func (c *testClient) Patch(ctx context.Context, name string, pt rest.PatchType, data []byte, opts metav1.UpdateOptions) (*corev1.ConfigFile, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	if len(opts.DryRun) == 0 {
+		return nil, errors.New("not a dry run")
+	}
+	return c.Client.Patch(ctx, name, pt, data, opts)
+}
+
+// testValidator names the API field path of an attribute.
+type testValidator string
+
+func (v testValidator) PathExpr() string { return string(v) }
+
+func (v testValidator) Description(context.Context) string { return "" }
+
+func (v testValidator) MarkdownDescription(context.Context) string { return "" }
+
+func (v testValidator) ValidateString(context.Context, validator.StringRequest, *validator.StringResponse) {
+}
+
+func testValue(name, data string) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, name),
+		"environment": tftypes.NewValue(tftypes.String, "dflt"),
+		"data":        tftypes.NewValue(tftypes.String, data),
+	}
+}
+
+// testModifyPlan runs ModifyPlan for a resource changing from state to plan.
+// A nil state creates the object.
+func testModifyPlan(t *testing.T, enabled bool, client dryrun.Client[*corev1.ConfigFile], state, plan map[string]tftypes.Value) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	typ := testSchema.Type().TerraformType(t.Context())
+	stateVal := tftypes.NewValue(typ, nil)
+	if state != nil {
+		stateVal = tftypes.NewValue(typ, state)
+	}
+	planVal := tftypes.NewValue(typ, plan)
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: testSchema, Raw: planVal},
+		Plan:   tfsdk.Plan{Schema: testSchema, Raw: planVal},
+		State:  tfsdk.State{Schema: testSchema, Raw: stateVal},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	dryrun.ModifyPlan(t.Context(), enabled, dryrun.Resource[*corev1.ConfigFile, testModel]{
+		Kind:     "ConfigFile",
+		Client:   func(testModel) dryrun.Client[*corev1.ConfigFile] { return client },
+		Name:     func(m testModel) string { return m.Name.ValueString() },
+		ToObject: testModel.ToObject,
+	}, req, resp)
+	return resp
+}
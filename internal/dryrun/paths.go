@@ -0,0 +1,137 @@
+package dryrun
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/tfutils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// pathExprValidator is implemented by validators that know the API field path
+// of their attribute, such as the GameFabric validators.
+type pathExprValidator interface {
+	PathExpr() string
+}
+
+// fieldPath maps an API field path expression to its attribute path.
+type fieldPath struct {
+	expr *regexp.Regexp
+	path path.Path
+}
+
+// fieldPaths collects the API field paths of all attributes in s that have a
+// GameFabric validator. More specific paths come first.
+func fieldPaths(s rschema.Schema) []fieldPath {
+	exprs := map[string]path.Path{}
+	tfutils.WalkResourceSchema(s, func(attr rschema.Attribute, p path.Path) {
+		for _, val := range tfutils.Validators(attr) {
+			if v, ok := val.(pathExprValidator); ok {
+				exprs[v.PathExpr()] = p
+			}
+		}
+	})
+
+	keys := make([]string, 0, len(exprs))
+	for k := range exprs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	res := make([]fieldPath, 0, len(keys))
+	for _, k := range keys {
+		// Variables match list indexes and map keys. The path must not continue
+		// with a name character, but may continue with a nested field.
+		expr := strings.ReplaceAll(regexp.QuoteMeta(k), `\?`, `([^\].\s]+)`)
+		res = append(res, fieldPath{
+			expr: regexp.MustCompile(`(?:^|[\s\[(,"'])` + expr + `(?:$|[^\w-])`),
+			path: exprs[k],
+		})
+	}
+	return res
+}
+
+// attributePaths returns the attribute paths of the API fields named in msg.
+// A field is attributed to the most specific known path containing it.
+func attributePaths(msg string, fps []fieldPath) []path.Path {
+	var (
+		res     []path.Path
+		matched [][2]int
+	)
+	for _, fp := range fps {
+		for _, m := range fp.expr.FindAllStringSubmatchIndex(msg, -1) {
+			if within(matched, m[0], m[1]) {
+				// A more specific path already matched here.
+				continue
+			}
+			matched = append(matched, [2]int{m[0], m[1]})
+
+			vars := make([]string, 0, len(m)/2-1)
+			for i := 2; i+1 < len(m); i += 2 {
+				vars = append(vars, msg[m[i]:m[i+1]])
+			}
+			p := withVars(fp.path, vars)
+			if !containsPath(res, p) {
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+func within(spans [][2]int, start, end int) bool {
+	for _, s := range spans {
+		if start >= s[0] && end <= s[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPath(paths []path.Path, p path.Path) bool {
+	for _, q := range paths {
+		if q.Equal(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// withVars replaces the element steps of p with the list indexes and map keys in vars.
+// The path is cut before the first step that can not be addressed, such as a set element.
+func withVars(p path.Path, vars []string) path.Path {
+	res := path.Empty()
+	for _, step := range p.Steps() {
+		switch step := step.(type) {
+		case path.PathStepAttributeName:
+			res = res.AtName(string(step))
+			continue
+		case path.PathStepElementKeyInt:
+			if len(vars) == 0 {
+				return res
+			}
+			idx, err := strconv.Atoi(vars[0])
+			if err != nil {
+				return res
+			}
+			res = res.AtListIndex(idx)
+		case path.PathStepElementKeyString:
+			if len(vars) == 0 {
+				return res
+			}
+			res = res.AtMapKey(vars[0])
+		default:
+			return res
+		}
+		vars = vars[1:]
+	}
+	return res
+}
@@ -0,0 +1,107 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributePaths(t *testing.T) {
+	t.Parallel()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Validators: []validator.String{testValidator("metadata.name")},
+			},
+			"containers": schema.ListNestedAttribute{
+				Validators: []validator.List{testValidator("spec.containers")},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"image": schema.StringAttribute{
+							Validators: []validator.String{testValidator("spec.containers[?].image")},
+						},
+						"env": schema.MapAttribute{
+							ElementType: types.StringType,
+							Validators:  []validator.Map{testValidator("spec.containers[?].env")},
+						},
+					},
+				},
+			},
+			"tags": schema.SetAttribute{
+				ElementType: types.StringType,
+			},
+		},
+	}
+	fps := fieldPaths(s)
+
+	tests := []struct {
+		name string
+		msg  string
+		want []path.Path
+	}{
+		{
+			name: "no known field",
+			msg:  `Armada.armada "test" is invalid: quota exceeded`,
+		},
+		{
+			name: "field",
+			msg:  `Armada.armada "test" is invalid: metadata.name: Invalid value: "Test"`,
+			want: []path.Path{path.Root("name")},
+		},
+		{
+			name: "list element",
+			msg:  `Armada.armada "test" is invalid: spec.containers[1].image: Required value`,
+			want: []path.Path{path.Root("containers").AtListIndex(1).AtName("image")},
+		},
+		{
+			name: "nested field of known field",
+			msg:  `Armada.armada "test" is invalid: spec.containers[0].env.KEY: Invalid value`,
+			want: []path.Path{path.Root("containers").AtListIndex(0).AtName("env")},
+		},
+		{
+			name: "multiple fields",
+			msg:  `Armada.armada "test" is invalid: [spec.containers[0].image: Required value, spec.containers: Too many: 3]`,
+			want: []path.Path{
+				path.Root("containers").AtListIndex(0).AtName("image"),
+				path.Root("containers"),
+			},
+		},
+		{
+			name: "field name prefix",
+			msg:  `Armada.armada "test" is invalid: metadata.namespace: Forbidden`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := attributePaths(test.msg, fps)
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+type testValidator string
+
+func (v testValidator) PathExpr() string { return string(v) }
+
+func (v testValidator) Description(context.Context) string { return "" }
+
+func (v testValidator) MarkdownDescription(context.Context) string { return "" }
+
+func (v testValidator) ValidateString(context.Context, validator.StringRequest, *validator.StringResponse) {
+}
+
+func (v testValidator) ValidateList(context.Context, validator.ListRequest, *validator.ListResponse) {
+}
+
+func (v testValidator) ValidateMap(context.Context, validator.MapRequest, *validator.MapResponse) {
+}
@@ -0,0 +1,100 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &serviceAccountPassword{}
+	_ ephemeral.EphemeralResourceWithConfigure = &serviceAccountPassword{}
+)
+
+type serviceAccountPassword struct {
+	clientSet clientset.Interface
+}
+
+// NewServiceAccountPassword creates a new service account password ephemeral resource.
+func NewServiceAccountPassword() ephemeral.EphemeralResource {
+	return &serviceAccountPassword{}
+}
+
+// Metadata defines the ephemeral resource type name.
+func (r *serviceAccountPassword) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_password"
+}
+
+// Schema defines the schema for this ephemeral resource.
+func (r *serviceAccountPassword) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"service_account": schema.StringAttribute{
+				Description:         "The name of the service account.",
+				MarkdownDescription: "The name of the service account.",
+				Required:            true,
+			},
+			"reset": schema.BoolAttribute{
+				Description:         "Whether to reset the password. Terraform opens ephemeral resources during both plan and apply, so only set it for the run that should rotate the password.",
+				MarkdownDescription: "Whether to reset the password. Terraform opens ephemeral resources during both plan and apply, so only set it for the run that should rotate the password.",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				Description:         "The new password of the service account, or null if reset is false. Resetting the password invalidates the previous one.",
+				MarkdownDescription: "The new password of the service account, or null if `reset` is false. Resetting the password invalidates the previous one.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Configure prepares the struct.
+func (r *serviceAccountPassword) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	procCtx, ok := req.ProviderData.(*provcontext.Context)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *provider.Context, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientSet = procCtx.ClientSet
+}
+
+func (r *serviceAccountPassword) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config serviceAccountPasswordModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Reset.ValueBool() {
+		config.Password = types.StringNull()
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+		return
+	}
+
+	password, err := r.clientSet.AuthenticationV1Beta1().ServiceAccounts().Reset(ctx, config.ServiceAccount.ValueString(), metav1.UpdateOptions{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Resetting Service Account Password",
+			fmt.Sprintf("Could not reset ServiceAccount password: %v", err),
+		)
+		return
+	}
+
+	config.Password = types.StringValue(password)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
@@ -0,0 +1,11 @@
+package authentication
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type serviceAccountPasswordModel struct {
+	ServiceAccount types.String `tfsdk:"service_account"`
+	Reset          types.Bool   `tfsdk:"reset"`
+	Password       types.String `tfsdk:"password"`
+}
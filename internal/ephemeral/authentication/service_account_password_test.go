@@ -0,0 +1,91 @@
+package authentication_test
+
+import (
+	"testing"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	authv1 "github.com/gamefabric/gf-core/pkg/api/authentication/v1beta1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/providertest"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestServiceAccountPassword(t *testing.T) {
+	t.Parallel()
+
+	serviceAccount := &authv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "svc-test",
+		},
+		Spec: authv1.ServiceAccountSpec{
+			Username: "svc-test",
+			Email:    "svc-test@ec.nitrado.systems",
+		},
+	}
+
+	pf, _ := providertest.ProtoV6ProviderFactories(t, serviceAccount)
+	pf["echo"] = echoprovider.NewProviderServer()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: pf,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `ephemeral "gamefabric_service_account_password" "test" {
+  service_account = "svc-test"
+  reset           = true
+}
+
+provider "echo" {
+  data = ephemeral.gamefabric_service_account_password.test
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.service_account", "svc-test"),
+					resource.TestCheckResourceAttr("echo.test", "data.password", "some-reset-password"),
+				),
+			},
+		},
+	})
+}
+
+func TestServiceAccountPassword_WithoutReset(t *testing.T) {
+	t.Parallel()
+
+	// The service account does not exist, so a reset would fail.
+	pf, _ := providertest.ProtoV6ProviderFactories(t)
+	pf["echo"] = echoprovider.NewProviderServer()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: pf,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `ephemeral "gamefabric_service_account_password" "test" {
+  service_account = "svc-test"
+  reset           = false
+}
+
+provider "echo" {
+  data = ephemeral.gamefabric_service_account_password.test
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.service_account", "svc-test"),
+					resource.TestCheckNoResourceAttr("echo.test", "data.password"),
+				),
+			},
+		},
+	})
+}
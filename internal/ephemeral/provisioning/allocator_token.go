@@ -0,0 +1,112 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &allocatorToken{}
+	_ ephemeral.EphemeralResourceWithConfigure = &allocatorToken{}
+)
+
+type allocatorToken struct {
+	clientSet clientset.Interface
+}
+
+// NewAllocatorToken creates a new allocator token ephemeral resource.
+func NewAllocatorToken() ephemeral.EphemeralResource {
+	return &allocatorToken{}
+}
+
+// Metadata defines the ephemeral resource type name.
+func (r *allocatorToken) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_allocator_token"
+}
+
+// Schema defines the schema for this ephemeral resource.
+func (r *allocatorToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description:         "The unique name of the managed allocator.",
+				MarkdownDescription: "The unique name of the managed allocator.",
+				Required:            true,
+			},
+			"allocation_url": schema.StringAttribute{
+				Description:         "The base URL of the allocation service endpoint. The endpoint returns a game server that matches the requested attributes.",
+				MarkdownDescription: "The base URL of the allocation service endpoint. The endpoint returns a game server that matches the requested attributes.",
+				Computed:            true,
+			},
+			"allocation_token": schema.StringAttribute{
+				Description:         "The active access token for the allocation service endpoint.",
+				MarkdownDescription: "The active access token for the allocation service endpoint.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"registry_url": schema.StringAttribute{
+				Description:         "The base URL of the registry service endpoint.",
+				MarkdownDescription: "The base URL of the registry service endpoint.",
+				Computed:            true,
+			},
+			"registry_token": schema.StringAttribute{
+				Description:         "The active access token for the registry service endpoint.",
+				MarkdownDescription: "The active access token for the registry service endpoint.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Configure prepares the struct.
+func (r *allocatorToken) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	procCtx, ok := req.ProviderData.(*provcontext.Context)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *provider.Context, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientSet = procCtx.ClientSet
+}
+
+func (r *allocatorToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config allocatorTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	obj, err := r.clientSet.ProvisioningV1Beta1().Allocators().Get(ctx, config.Name.ValueString(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Allocator Not Found",
+				fmt.Sprintf("Allocator %q was not found.", config.Name.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Getting Allocator",
+			fmt.Sprintf("Could not get Allocator %q: %v", config.Name.ValueString(), err),
+		)
+		return
+	}
+
+	result := newAllocatorTokenModel(obj)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
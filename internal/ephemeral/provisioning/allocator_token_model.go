@@ -0,0 +1,25 @@
+package provisioning
+
+import (
+	provisioningv1beta1 "github.com/gamefabric/gf-core/pkg/api/provisioning/v1beta1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type allocatorTokenModel struct {
+	Name            types.String `tfsdk:"name"`
+	AllocationURL   types.String `tfsdk:"allocation_url"`
+	AllocationToken types.String `tfsdk:"allocation_token"`
+	RegistryURL     types.String `tfsdk:"registry_url"`
+	RegistryToken   types.String `tfsdk:"registry_token"`
+}
+
+func newAllocatorTokenModel(obj *provisioningv1beta1.Allocator) allocatorTokenModel {
+	return allocatorTokenModel{
+		Name:            types.StringValue(obj.Name),
+		AllocationURL:   conv.OptionalFunc(obj.Status.Allocation.URL, types.StringValue, types.StringNull),
+		AllocationToken: conv.LastOrNull(obj.Status.Allocation.Tokens),
+		RegistryURL:     conv.OptionalFunc(obj.Status.Registration.URL, types.StringValue, types.StringNull),
+		RegistryToken:   conv.LastOrNull(obj.Status.Registration.Tokens),
+	}
+}
@@ -0,0 +1,67 @@
+package provisioning_test
+
+import (
+	"testing"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	provisioningv1beta1 "github.com/gamefabric/gf-core/pkg/api/provisioning/v1beta1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/providertest"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAllocatorToken(t *testing.T) {
+	t.Parallel()
+
+	alloc := &provisioningv1beta1.Allocator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-allocator",
+		},
+		Spec: provisioningv1beta1.AllocatorSpec{
+			Region: "eu-west",
+		},
+		Status: provisioningv1beta1.AllocatorStatus{
+			Allocation: provisioningv1beta1.AllocatorEndpoint{
+				URL:    "https://alloc.example.com",
+				Tokens: []string{"alloc-token-old", "alloc-token-new"},
+			},
+			Registration: provisioningv1beta1.AllocatorEndpoint{
+				URL:    "https://reg.example.com",
+				Tokens: []string{"reg-token-old", "reg-token-new"},
+			},
+		},
+	}
+
+	pf, _ := providertest.ProtoV6ProviderFactories(t, alloc)
+	pf["echo"] = echoprovider.NewProviderServer()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: pf,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `ephemeral "gamefabric_allocator_token" "test" {
+  name = "test-allocator"
+}
+
+provider "echo" {
+  data = ephemeral.gamefabric_allocator_token.test
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.name", "test-allocator"),
+					resource.TestCheckResourceAttr("echo.test", "data.allocation_url", "https://alloc.example.com"),
+					resource.TestCheckResourceAttr("echo.test", "data.allocation_token", "alloc-token-new"),
+					resource.TestCheckResourceAttr("echo.test", "data.registry_url", "https://reg.example.com"),
+					resource.TestCheckResourceAttr("echo.test", "data.registry_token", "reg-token-new"),
+				),
+			},
+		},
+	})
+}
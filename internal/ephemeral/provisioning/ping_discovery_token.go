@@ -0,0 +1,101 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &pingDiscoveryToken{}
+	_ ephemeral.EphemeralResourceWithConfigure = &pingDiscoveryToken{}
+)
+
+type pingDiscoveryToken struct {
+	clientSet clientset.Interface
+}
+
+// NewPingDiscoveryToken creates a new ping discovery token ephemeral resource.
+func NewPingDiscoveryToken() ephemeral.EphemeralResource {
+	return &pingDiscoveryToken{}
+}
+
+// Metadata defines the ephemeral resource type name.
+func (r *pingDiscoveryToken) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ping_discovery_token"
+}
+
+// Schema defines the schema for this ephemeral resource.
+func (r *pingDiscoveryToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description:         "The unique ping discovery name.",
+				MarkdownDescription: "The unique ping discovery name.",
+				Required:            true,
+			},
+			"url": schema.StringAttribute{
+				Description:         "The base URL of the ping discovery endpoint.",
+				MarkdownDescription: "The base URL of the ping discovery endpoint.",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				Description:         "The active access token for the ping discovery endpoint.",
+				MarkdownDescription: "The active access token for the ping discovery endpoint.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Configure prepares the struct.
+func (r *pingDiscoveryToken) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	procCtx, ok := req.ProviderData.(*provcontext.Context)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *provider.Context, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientSet = procCtx.ClientSet
+}
+
+func (r *pingDiscoveryToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config pingDiscoveryTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	obj, err := r.clientSet.ProvisioningV1Beta1().PingDiscoveries().Get(ctx, config.Name.ValueString(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Ping Discovery Not Found",
+				fmt.Sprintf("Ping Discovery %q was not found.", config.Name.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Getting Ping Discovery",
+			fmt.Sprintf("Could not get Ping Discovery %q: %v", config.Name.ValueString(), err),
+		)
+		return
+	}
+
+	result := newPingDiscoveryTokenModel(obj)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
@@ -0,0 +1,21 @@
+package provisioning
+
+import (
+	provisioningv1beta1 "github.com/gamefabric/gf-core/pkg/api/provisioning/v1beta1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type pingDiscoveryTokenModel struct {
+	Name  types.String `tfsdk:"name"`
+	URL   types.String `tfsdk:"url"`
+	Token types.String `tfsdk:"token"`
+}
+
+func newPingDiscoveryTokenModel(obj *provisioningv1beta1.PingDiscovery) pingDiscoveryTokenModel {
+	return pingDiscoveryTokenModel{
+		Name:  types.StringValue(obj.Name),
+		URL:   conv.OptionalFunc(obj.Status.URL, types.StringValue, types.StringNull),
+		Token: conv.LastOrNull(obj.Status.Tokens),
+	}
+}
@@ -0,0 +1,69 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dynamicbuffer"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &dynamicBufferThresholds{}
+
+type dynamicBufferThresholdsModel struct {
+	DynamicMaxBufferThreshold types.Int32 `tfsdk:"dynamic_max_buffer_threshold"`
+	DynamicMinBufferThreshold types.Int32 `tfsdk:"dynamic_min_buffer_threshold"`
+}
+
+type dynamicBufferThresholds struct{}
+
+// NewDynamicBufferThresholds returns the dynamic_buffer_thresholds function.
+func NewDynamicBufferThresholds() function.Function {
+	return &dynamicBufferThresholds{}
+}
+
+// Metadata defines the function name.
+func (f *dynamicBufferThresholds) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dynamic_buffer_thresholds"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *dynamicBufferThresholds) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Calculates the dynamic buffer thresholds of a maximum buffer utilization.",
+		Description:         "Returns the dynamic maximum and minimum buffer thresholds that GameFabric derives from the given maximum buffer utilization when they are not set.",
+		MarkdownDescription: "Returns the `dynamic_max_buffer_threshold` and `dynamic_min_buffer_threshold` that GameFabric derives from the given `max_buffer_utilization` when they are not set.",
+		Parameters: []function.Parameter{
+			function.Int32Parameter{
+				Name:                "max_buffer_utilization",
+				Description:         "The maximum buffer utilization in percent, between 1 and 100.",
+				MarkdownDescription: "The maximum buffer utilization in percent, between 1 and 100.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"dynamic_max_buffer_threshold": types.Int32Type,
+				"dynamic_min_buffer_threshold": types.Int32Type,
+			},
+		},
+	}
+}
+
+func (f *dynamicBufferThresholds) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var mbu int32
+	resp.Error = req.Arguments.Get(ctx, &mbu)
+	if resp.Error != nil {
+		return
+	}
+	if mbu < 1 || mbu > 100 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The maximum buffer utilization must be between 1 and 100, got %d.", mbu))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, dynamicBufferThresholdsModel{
+		DynamicMaxBufferThreshold: types.Int32Value(dynamicbuffer.DynamicMaxBufferThreshold(mbu)),
+		DynamicMinBufferThreshold: types.Int32Value(dynamicbuffer.DynamicMinBufferThreshold(mbu)),
+	})
+}
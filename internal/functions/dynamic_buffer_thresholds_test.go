@@ -0,0 +1,65 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/functions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicBufferThresholds(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"dynamic_max_buffer_threshold": types.Int32Type,
+		"dynamic_min_buffer_threshold": types.Int32Type,
+	}
+
+	tests := []struct {
+		name      string
+		mbu       int32
+		wantMax   int32
+		wantMin   int32
+		wantError bool
+	}{
+		{name: "above table", mbu: 85, wantMax: 100, wantMin: 50},
+		{name: "exact match", mbu: 45, wantMax: 200, wantMin: 30},
+		{name: "interpolated", mbu: 48, wantMax: 166, wantMin: 32},
+		{name: "below table", mbu: 8, wantMax: 300, wantMin: 10},
+		{name: "maximum", mbu: 100, wantMax: 100, wantMin: 50},
+		{name: "zero", mbu: 0, wantError: true},
+		{name: "negative", mbu: -5, wantError: true},
+		{name: "above 100", mbu: 101, wantError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.Int32Value(test.mbu)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(attrTypes)),
+			}
+
+			functions.NewDynamicBufferThresholds().Run(t.Context(), req, resp)
+
+			if test.wantError {
+				require.NotNil(t, resp.Error)
+				assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+			require.Nil(t, resp.Error)
+			want := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"dynamic_max_buffer_threshold": types.Int32Value(test.wantMax),
+				"dynamic_min_buffer_threshold": types.Int32Value(test.wantMin),
+			})
+			assert.Equal(t, want, resp.Result.Value())
+		})
+	}
+}
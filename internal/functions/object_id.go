@@ -0,0 +1,58 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/gamefabric/gf-apiclient/tools/cache"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &objectID{}
+
+type objectID struct{}
+
+// NewObjectID returns the object_id function.
+func NewObjectID() function.Function {
+	return &objectID{}
+}
+
+// Metadata defines the function name.
+func (f *objectID) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *objectID) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds the ID of an object.",
+		Description:         "Returns the ID of an object as used by the id attribute and import of resources. The ID of a cluster-scoped object, which has an empty environment, is its name.",
+		MarkdownDescription: "Returns the ID of an object as used by the `id` attribute and import of resources. The ID of a cluster-scoped object, which has an empty environment, is its name.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "environment",
+				Description:         "The name of the environment the object belongs to.",
+				MarkdownDescription: "The name of the environment the object belongs to.",
+			},
+			function.StringParameter{
+				Name:                "name",
+				Description:         "The name of the object.",
+				MarkdownDescription: "The name of the object.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *objectID) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var env, name string
+	resp.Error = req.Arguments.Get(ctx, &env, &name)
+	if resp.Error != nil {
+		return
+	}
+	if name == "" {
+		resp.Error = function.NewArgumentFuncError(1, "The name must not be empty.")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, cache.NewObjectName(env, name).String())
+}
@@ -0,0 +1,49 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/functions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		env, obj  string
+		want      string
+		wantError bool
+	}{
+		{name: "namespaced", env: "dflt", obj: "my-armada", want: "dflt/my-armada"},
+		{name: "cluster-scoped", env: "", obj: "my-branch", want: "my-branch"},
+		{name: "empty name", env: "dflt", obj: "", wantError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.env), types.StringValue(test.obj)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			functions.NewObjectID().Run(t.Context(), req, resp)
+
+			if test.wantError {
+				assert.NotNil(t, resp.Error)
+				return
+			}
+			require.Nil(t, resp.Error)
+			assert.Equal(t, types.StringValue(test.want), resp.Result.Value())
+		})
+	}
+}
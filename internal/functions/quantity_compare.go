@@ -0,0 +1,66 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ function.Function = &quantityCompare{}
+
+type quantityCompare struct{}
+
+// NewQuantityCompare returns the quantity_compare function.
+func NewQuantityCompare() function.Function {
+	return &quantityCompare{}
+}
+
+// Metadata defines the function name.
+func (f *quantityCompare) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "quantity_compare"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *quantityCompare) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Compares two resource quantities.",
+		Description:         "Returns -1 if a is less than b, 0 if they are equal and 1 if a is greater than b. Quantities are compared by value, so \"1Gi\" equals \"1024Mi\" and \"500m\" equals \"0.5\".",
+		MarkdownDescription: "Returns `-1` if `a` is less than `b`, `0` if they are equal and `1` if `a` is greater than `b`. Quantities are compared by value, so `\"1Gi\"` equals `\"1024Mi\"` and `\"500m\"` equals `\"0.5\"`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "a",
+				Description:         "The first quantity, for example \"250m\" or \"1Gi\".",
+				MarkdownDescription: "The first quantity, for example `\"250m\"` or `\"1Gi\"`.",
+			},
+			function.StringParameter{
+				Name:                "b",
+				Description:         "The second quantity.",
+				MarkdownDescription: "The second quantity.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *quantityCompare) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = req.Arguments.Get(ctx, &a, &b)
+	if resp.Error != nil {
+		return
+	}
+
+	qa, err := resource.ParseQuantity(a)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a valid quantity: %v", a, err))
+		return
+	}
+	qb, err := resource.ParseQuantity(b)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q is not a valid quantity: %v", b, err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, int64(qa.Cmp(qb)))
+}
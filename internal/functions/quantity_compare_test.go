@@ -0,0 +1,53 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/functions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantityCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		a, b    string
+		want    int64
+		wantErr string
+	}{
+		{name: "less", a: "250m", b: "1", want: -1},
+		{name: "equal with different units", a: "1Gi", b: "1024Mi", want: 0},
+		{name: "equal decimal and milli", a: "0.5", b: "500m", want: 0},
+		{name: "greater", a: "2G", b: "1Gi", want: 1},
+		{name: "invalid first", a: "abc", b: "1", wantErr: `"abc" is not a valid quantity`},
+		{name: "invalid second", a: "1", b: "1XB", wantErr: `"1XB" is not a valid quantity`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.a), types.StringValue(test.b)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.Int64Unknown()),
+			}
+
+			functions.NewQuantityCompare().Run(t.Context(), req, resp)
+
+			if test.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				assert.Contains(t, resp.Error.Error(), test.wantErr)
+				return
+			}
+			require.Nil(t, resp.Error)
+			assert.Equal(t, types.Int64Value(test.want), resp.Result.Value())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gamefabric/gf-apiclient/tools/cache"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &splitObjectID{}
+
+type splitObjectIDModel struct {
+	Environment types.String `tfsdk:"environment"`
+	Name        types.String `tfsdk:"name"`
+}
+
+type splitObjectID struct{}
+
+// NewSplitObjectID returns the split_object_id function.
+func NewSplitObjectID() function.Function {
+	return &splitObjectID{}
+}
+
+// Metadata defines the function name.
+func (f *splitObjectID) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_object_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *splitObjectID) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Splits the ID of an object into its environment and name.",
+		Description:         "Returns the environment and name of an object ID as built by object_id. The environment of a cluster-scoped object is empty.",
+		MarkdownDescription: "Returns the `environment` and `name` of an object ID as built by `object_id`. The environment of a cluster-scoped object is empty.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				Description:         "The ID of the object, for example \"dflt/my-armada\".",
+				MarkdownDescription: "The ID of the object, for example `\"dflt/my-armada\"`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"environment": types.StringType,
+				"name":        types.StringType,
+			},
+		},
+	}
+}
+
+func (f *splitObjectID) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	env, name := cache.SplitMetaNamespaceKey(id)
+	if name == "" || strings.Contains(name, "/") {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a valid object ID, expected \"environment/name\" or \"name\".", id))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, splitObjectIDModel{
+		Environment: types.StringValue(env),
+		Name:        types.StringValue(name),
+	})
+}
@@ -0,0 +1,60 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/functions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitObjectID(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"environment": types.StringType,
+		"name":        types.StringType,
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		wantEnv   string
+		wantName  string
+		wantError bool
+	}{
+		{name: "namespaced", id: "dflt/my-armada", wantEnv: "dflt", wantName: "my-armada"},
+		{name: "cluster-scoped", id: "my-branch", wantEnv: "", wantName: "my-branch"},
+		{name: "empty", id: "", wantError: true},
+		{name: "too many parts", id: "dflt/my-armada/extra", wantError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.id)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(attrTypes)),
+			}
+
+			functions.NewSplitObjectID().Run(t.Context(), req, resp)
+
+			if test.wantError {
+				assert.NotNil(t, resp.Error)
+				return
+			}
+			require.Nil(t, resp.Error)
+			want := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"environment": types.StringValue(test.wantEnv),
+				"name":        types.StringValue(test.wantName),
+			})
+			assert.Equal(t, want, resp.Result.Value())
+		})
+	}
+}
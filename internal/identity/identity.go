@@ -0,0 +1,101 @@
+// Package identity provides the resource identities of GameFabric objects, which allow importing
+// a resource by its identity in Terraform 1.12 and later instead of an import ID.
+package identity
+
+import (
+	"context"
+
+	"github.com/gamefabric/gf-apiclient/tools/cache"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Namespaced is the identity of an object within an environment.
+type Namespaced struct {
+	Environment types.String `tfsdk:"environment"`
+	Name        types.String `tfsdk:"name"`
+}
+
+// Cluster is the identity of a cluster-scoped object.
+type Cluster struct {
+	Name types.String `tfsdk:"name"`
+}
+
+// NamespacedSchema returns the identity schema of an object within an environment.
+func NamespacedSchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"environment": identityschema.StringAttribute{
+				Description:       "The name of the environment the object belongs to. Defaults to the provider's default environment.",
+				OptionalForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The unique object name within its environment.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// ClusterSchema returns the identity schema of a cluster-scoped object.
+func ClusterSchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The unique object name.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// Set sets the identity of a resource to v.
+// It does nothing if Terraform does not support resource identities.
+func Set(ctx context.Context, identity *tfsdk.ResourceIdentity, v any) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, v)
+}
+
+// ImportNamespaced imports an object within an environment, either from an
+// import ID of the form "environment/name" or from its identity. If no
+// environment is given, defaultEnv is used. The name is stored in the
+// state at namePath.
+func ImportNamespaced(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, namePath path.Path, defaultEnv types.String) {
+	var env, name string
+	switch {
+	case req.ID != "":
+		env, name = cache.SplitMetaNamespaceKey(req.ID)
+	case req.Identity != nil:
+		var id Namespaced
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		env, name = id.Environment.ValueString(), id.Name.ValueString()
+	default:
+		return
+	}
+	if env == "" {
+		env = defaultEnv.ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, namePath, name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+	resp.Diagnostics.Append(Set(ctx, resp.Identity, Namespaced{
+		Environment: types.StringValue(env),
+		Name:        types.StringValue(name),
+	})...)
+}
+
+// ImportCluster imports a cluster-scoped object, either from an import ID
+// holding its name or from its identity. The name is stored in the state
+// at namePath.
+func ImportCluster(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, namePath path.Path) {
+	resource.ImportStatePassthroughWithIdentity(ctx, namePath, path.Root("name"), req, resp)
+}
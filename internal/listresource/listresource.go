@@ -0,0 +1,273 @@
+// Package listresource implements the list resources, which allow `terraform query`
+// to discover existing objects, and provides their shared configuration and results.
+package listresource
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NamespacedModel is the list configuration of objects within an environment.
+type NamespacedModel struct {
+	Environment types.String            `tfsdk:"environment"`
+	LabelFilter map[string]types.String `tfsdk:"label_filter"`
+}
+
+// ClusterModel is the list configuration of cluster-scoped objects.
+type ClusterModel struct {
+	LabelFilter map[string]types.String `tfsdk:"label_filter"`
+}
+
+// NamespacedSchema returns the list configuration schema of objects within an environment.
+// The plural is the name of the listed objects used in descriptions, for example "armadas".
+func NamespacedSchema(plural string) schema.Schema {
+	desc := fmt.Sprintf("Lists the %s in an environment, so that `terraform query` can discover existing %s and generate their import blocks and configuration.", plural, plural)
+	return schema.Schema{
+		Description:         desc,
+		MarkdownDescription: desc,
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Description:         fmt.Sprintf("The name of the environment to list %s in. Defaults to the provider's default environment.", plural),
+				MarkdownDescription: fmt.Sprintf("The name of the environment to list %s in. Defaults to the provider's `default_environment`.", plural),
+				Optional:            true,
+				Validators: []validator.String{
+					validators.EnvironmentValidator{},
+				},
+			},
+			"label_filter": labelFilterAttribute(plural),
+		},
+	}
+}
+
+// ClusterSchema returns the list configuration schema of cluster-scoped objects.
+// The plural is the name of the listed objects used in descriptions, for example "branches".
+func ClusterSchema(plural string) schema.Schema {
+	desc := fmt.Sprintf("Lists the %s, so that `terraform query` can discover existing %s and generate their import blocks and configuration.", plural, plural)
+	return schema.Schema{
+		Description:         desc,
+		MarkdownDescription: desc,
+		Attributes: map[string]schema.Attribute{
+			"label_filter": labelFilterAttribute(plural),
+		},
+	}
+}
+
+func labelFilterAttribute(plural string) schema.MapAttribute {
+	return schema.MapAttribute{
+		Description:         fmt.Sprintf("A map of keys and values that is used to filter %s. Only items with all specified labels (exact matches) will be returned.", plural),
+		MarkdownDescription: fmt.Sprintf("A map of keys and values that is used to filter %s. Only items with all specified labels (exact matches) will be returned.", plural),
+		Optional:            true,
+		ElementType:         types.StringType,
+	}
+}
+
+// Environment returns the configured environment, or defaultEnv if none is configured.
+func Environment(configured, defaultEnv types.String) (string, diag.Diagnostics) {
+	env := configured.ValueString()
+	if env == "" {
+		env = defaultEnv.ValueString()
+	}
+	if env == "" {
+		return "", diag.Diagnostics{diag.NewErrorDiagnostic(
+			"Missing Environment",
+			"The environment must be configured in the list block or with the provider's default_environment.",
+		)}
+	}
+	return env, nil
+}
+
+// LabelSelector returns the label filter as a label selector.
+func LabelSelector(filter map[string]types.String) map[string]string {
+	return conv.ForEachMapItem(filter, func(item types.String) string { return item.ValueString() })
+}
+
+// Result is a listed object.
+type Result struct {
+	// DisplayName is the human-readable name of the object.
+	DisplayName string
+	// Identity is the identity of the object, for example identity.Namespaced.
+	Identity any
+	// Resource is the resource model of the object.
+	Resource any
+}
+
+// Stream returns the list results of items, ordered by display name.
+//
+// The resource model of an item is only set if it was requested, and no more
+// results than the requested limit are returned.
+func Stream[T any](ctx context.Context, req list.ListRequest, items []T, fn func(item *T) Result) iter.Seq[list.ListResult] {
+	results := make([]Result, 0, len(items))
+	for i := range items {
+		results = append(results, fn(&items[i]))
+	}
+	slices.SortFunc(results, func(a, b Result) int {
+		return cmp.Compare(a.DisplayName, b.DisplayName)
+	})
+	if req.Limit > 0 && int64(len(results)) > req.Limit {
+		results = results[:req.Limit]
+	}
+
+	return func(push func(list.ListResult) bool) {
+		for _, res := range results {
+			result := req.NewListResult(ctx)
+			result.DisplayName = res.DisplayName
+			result.Diagnostics.Append(result.Identity.Set(ctx, res.Identity)...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, res.Resource)...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// Error returns the list results of a failed list, for example of "Armadas".
+func Error(plural string, err error) iter.Seq[list.ListResult] {
+	return list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic(
+		"Error Listing "+plural,
+		fmt.Sprintf("Could not list %s: %v", plural, err),
+	)})
+}
+
+// Options configures a list resource of objects of type T.
+type Options[T any] struct {
+	// TypeName is the resource type name without the provider prefix, for example "armada".
+	TypeName string
+	// Plural is the name of the listed objects used in descriptions, for example "armadas".
+	Plural string
+	// Kinds is the plural kind of the listed objects used in errors, for example "Armadas".
+	Kinds string
+	// Note is appended to the schema description.
+	Note string
+
+	// List lists the objects matching opts. The env is empty for cluster-scoped objects.
+	List func(ctx context.Context, cs clientset.Interface, env string, opts metav1.ListOptions) ([]T, error)
+	// Result returns the list result of obj. The provider's default labels and
+	// annotations are removed from the resource model.
+	Result func(obj *T, defaults Defaults) Result
+}
+
+// Defaults are the provider's default labels and annotations.
+type Defaults struct {
+	Labels      types.Map
+	Annotations types.Map
+}
+
+// NewNamespaced returns a list resource of objects within an environment.
+func NewNamespaced[T any](opts Options[T]) list.ListResource {
+	return &listResource[T]{opts: opts, namespaced: true}
+}
+
+// NewCluster returns a list resource of cluster-scoped objects.
+func NewCluster[T any](opts Options[T]) list.ListResource {
+	return &listResource[T]{opts: opts}
+}
+
+var (
+	_ list.ListResource              = &listResource[any]{}
+	_ list.ListResourceWithConfigure = &listResource[any]{}
+)
+
+type listResource[T any] struct {
+	opts       Options[T]
+	namespaced bool
+
+	clientSet          clientset.Interface
+	defaultEnvironment types.String
+	defaults           Defaults
+}
+
+// Metadata defines the list resource type name.
+func (r *listResource[T]) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.opts.TypeName
+}
+
+// ListResourceConfigSchema defines the schema of the list configuration.
+func (r *listResource[T]) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	if r.namespaced {
+		resp.Schema = NamespacedSchema(r.opts.Plural)
+	} else {
+		resp.Schema = ClusterSchema(r.opts.Plural)
+	}
+	if r.opts.Note != "" {
+		resp.Schema.Description += " " + r.opts.Note
+		resp.Schema.MarkdownDescription += " " + r.opts.Note
+	}
+}
+
+// Configure prepares the struct.
+func (r *listResource[T]) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	procCtx, ok := req.ProviderData.(*provcontext.Context)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *provider.Context, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientSet = procCtx.ClientSet
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaults = Defaults{Labels: procCtx.DefaultLabels, Annotations: procCtx.DefaultAnnotations}
+}
+
+func (r *listResource[T]) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var (
+		env         string
+		labelFilter map[string]types.String
+	)
+	if r.namespaced {
+		var config NamespacedModel
+		diags := req.Config.Get(ctx, &config)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		env, diags = Environment(config.Environment, r.defaultEnvironment)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		labelFilter = config.LabelFilter
+	} else {
+		var config ClusterModel
+		diags := req.Config.Get(ctx, &config)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		labelFilter = config.LabelFilter
+	}
+
+	items, err := r.opts.List(ctx, r.clientSet, env, metav1.ListOptions{
+		LabelSelector: LabelSelector(labelFilter),
+	})
+	if err != nil {
+		stream.Results = Error(r.opts.Kinds, err)
+		return
+	}
+
+	stream.Results = Stream(ctx, req, items, func(obj *T) Result {
+		return r.opts.Result(obj, r.defaults)
+	})
+}
@@ -0,0 +1,215 @@
+package listresource_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testModel struct {
+	Name        types.String `tfsdk:"name"`
+	Environment types.String `tfsdk:"environment"`
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		limit           int64
+		includeResource bool
+		want            []string
+	}{
+		{
+			name: "returns sorted results",
+			want: []string{"dflt/a", "dflt/b", "dflt/c"},
+		},
+		{
+			name:  "stops at limit",
+			limit: 2,
+			want:  []string{"dflt/a", "dflt/b"},
+		},
+		{
+			name:            "includes resource",
+			includeResource: true,
+			want:            []string{"dflt/a", "dflt/b", "dflt/c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := list.ListRequest{
+				Limit:           test.limit,
+				IncludeResource: test.includeResource,
+				ResourceSchema: schema.Schema{
+					Attributes: map[string]schema.Attribute{
+						"name":        schema.StringAttribute{Required: true},
+						"environment": schema.StringAttribute{Required: true},
+					},
+				},
+				ResourceIdentitySchema: identity.NamespacedSchema(),
+			}
+			items := []string{"c", "a", "b"}
+
+			got := listresource.Stream(t.Context(), req, items, func(item *string) listresource.Result {
+				m := testModel{Name: types.StringValue(*item), Environment: types.StringValue("dflt")}
+				return listresource.Result{
+					DisplayName: "dflt/" + *item,
+					Identity:    identity.Namespaced{Environment: m.Environment, Name: m.Name},
+					Resource:    m,
+				}
+			})
+
+			var names []string
+			for result := range got {
+				require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+				names = append(names, result.DisplayName)
+
+				var id identity.Namespaced
+				require.False(t, result.Identity.Get(t.Context(), &id).HasError())
+				assert.Equal(t, "dflt/"+id.Name.ValueString(), result.DisplayName)
+
+				var name types.String
+				require.False(t, result.Resource.GetAttribute(t.Context(), path.Root("name"), &name).HasError())
+				assert.Equal(t, test.includeResource, !name.IsNull())
+			}
+			assert.Equal(t, test.want, names)
+		})
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	t.Parallel()
+
+	env, diags := listresource.Environment(types.StringValue("prod"), types.StringValue("dflt"))
+	require.False(t, diags.HasError())
+	assert.Equal(t, "prod", env)
+
+	env, diags = listresource.Environment(types.StringNull(), types.StringValue("dflt"))
+	require.False(t, diags.HasError())
+	assert.Equal(t, "dflt", env)
+
+	_, diags = listresource.Environment(types.StringNull(), types.StringNull())
+	assert.True(t, diags.HasError())
+}
+
+func TestNewNamespaced(t *testing.T) {
+	t.Parallel()
+
+	var gotEnv string
+	var gotOpts metav1.ListOptions
+	r := listresource.NewNamespaced(listresource.Options[string]{
+		TypeName: "test",
+		Plural:   "tests",
+		Kinds:    "Tests",
+		List: func(_ context.Context, _ clientset.Interface, env string, opts metav1.ListOptions) ([]string, error) {
+			gotEnv, gotOpts = env, opts
+			return []string{"b", "a"}, nil
+		},
+		Result: func(item *string, defaults listresource.Defaults) listresource.Result {
+			assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("ops")}), defaults.Labels)
+			return listresource.Result{
+				DisplayName: *item,
+				Identity:    identity.Namespaced{Environment: types.StringValue(gotEnv), Name: types.StringValue(*item)},
+			}
+		},
+	})
+
+	provCtx := provcontext.NewContext(nil)
+	provCtx.DefaultEnvironment = types.StringValue("dflt")
+	provCtx.DefaultLabels = types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("ops")})
+
+	metaResp := &resource.MetadataResponse{}
+	r.Metadata(t.Context(), resource.MetadataRequest{ProviderTypeName: "gamefabric"}, metaResp)
+	assert.Equal(t, "gamefabric_test", metaResp.TypeName)
+
+	schemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(t.Context(), list.ListResourceSchemaRequest{}, schemaResp)
+	require.Contains(t, schemaResp.Schema.Attributes, "environment")
+
+	configureResp := &resource.ConfigureResponse{}
+	r.(list.ListResourceWithConfigure).Configure(t.Context(), resource.ConfigureRequest{ProviderData: provCtx}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	stream := &list.ListResultsStream{}
+	r.List(t.Context(), list.ListRequest{
+		Config: testListConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"environment": tftypes.NewValue(tftypes.String, nil),
+			"label_filter": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"tier": tftypes.NewValue(tftypes.String, "prod"),
+			}),
+		}),
+		ResourceSchema:         schema.Schema{},
+		ResourceIdentitySchema: identity.NamespacedSchema(),
+	}, stream)
+
+	var names []string
+	for result := range stream.Results {
+		require.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+		names = append(names, result.DisplayName)
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+	assert.Equal(t, "dflt", gotEnv)
+	assert.Equal(t, map[string]string{"tier": "prod"}, gotOpts.LabelSelector)
+}
+
+func TestNewCluster_ListError(t *testing.T) {
+	t.Parallel()
+
+	r := listresource.NewCluster(listresource.Options[string]{
+		TypeName: "test",
+		Plural:   "tests",
+		Kinds:    "Tests",
+		List: func(context.Context, clientset.Interface, string, metav1.ListOptions) ([]string, error) {
+			return nil, errors.New("boom")
+		},
+	})
+
+	schemaResp := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(t.Context(), list.ListResourceSchemaRequest{}, schemaResp)
+	require.NotContains(t, schemaResp.Schema.Attributes, "environment")
+
+	stream := &list.ListResultsStream{}
+	r.List(t.Context(), list.ListRequest{
+		Config: testListConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"label_filter": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		}),
+	}, stream)
+
+	var diags diag.Diagnostics
+	for result := range stream.Results {
+		diags.Append(result.Diagnostics...)
+	}
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Error Listing Tests", diags[0].Summary())
+	assert.Equal(t, "Could not list Tests: boom", diags[0].Detail())
+}
+
+func testListConfig(t *testing.T, s listschema.Schema, vals map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	return tfsdk.Config{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(t.Context()), vals),
+	}
+}
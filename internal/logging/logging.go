@@ -0,0 +1,112 @@
+// Package logging provides helpers to trace the documents sent to the GameFabric API with tflog.
+// Sensitive values are redacted before a document is logged.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Redacted replaces sensitive values in logged documents.
+const Redacted = "<redacted>"
+
+// sensitiveKeys are the keys whose values are redacted in every document, at any depth.
+// Keys are compared case-insensitively and ignoring underscores.
+var sensitiveKeys = []string{
+	"password",
+	"secretaccesskey",
+	"clientsecret",
+}
+
+// Object logs the object sent to create an object of the given kind at DEBUG.
+// The values of keys are redacted in addition to the sensitive keys.
+func Object(ctx context.Context, kind string, obj any, keys ...string) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		tflog.Debug(ctx, "Could not encode object for logging", map[string]any{"kind": kind, "error": err.Error()})
+		return
+	}
+	tflog.Debug(ctx, "Creating object", map[string]any{
+		"kind":   kind,
+		"object": string(Redact(b, keys...)),
+	})
+}
+
+// Patch logs the merge patch sent to update an object of the given kind at DEBUG.
+// The values of keys are redacted in addition to the sensitive keys.
+func Patch(ctx context.Context, kind string, pb []byte, keys ...string) {
+	tflog.Debug(ctx, "Patching object", map[string]any{
+		"kind":  kind,
+		"patch": string(Redact(pb, keys...)),
+	})
+}
+
+// Redact returns the JSON document doc with the values of the sensitive keys
+// and the given keys replaced. The keys of redacted objects are kept, so it
+// remains visible which entries changed. If doc cannot be decoded, it is
+// redacted entirely.
+func Redact(doc []byte, keys ...string) []byte {
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return []byte(`"` + Redacted + `"`)
+	}
+
+	redactKeys := make(map[string]bool, len(sensitiveKeys)+len(keys))
+	for _, k := range sensitiveKeys {
+		redactKeys[k] = true
+	}
+	for _, k := range keys {
+		redactKeys[normalizeKey(k)] = true
+	}
+
+	b, err := json.Marshal(redact(v, redactKeys))
+	if err != nil {
+		return []byte(`"` + Redacted + `"`)
+	}
+	return b
+}
+
+func redact(v any, keys map[string]bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if keys[normalizeKey(k)] {
+				v[k] = redactValue(val)
+				continue
+			}
+			v[k] = redact(val, keys)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = redact(val, keys)
+		}
+	}
+	return v
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case nil:
+		// A null value removes the field in a merge patch and reveals nothing.
+		return nil
+	case map[string]any:
+		for k, val := range v {
+			v[k] = redactValue(val)
+		}
+		return v
+	case []any:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+		return v
+	default:
+		return Redacted
+	}
+}
+
+func normalizeKey(k string) string {
+	return strings.ToLower(strings.ReplaceAll(k, "_", ""))
+}
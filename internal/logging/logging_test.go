@@ -0,0 +1,95 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+		keys []string
+		want string
+	}{
+		{
+			name: "sensitive keys at any depth",
+			doc:  `{"spec":{"s3":{"auth":{"accessKeyId":"AKIA","secretAccessKey":"s3cr3t"}}},"password":"pw"}`,
+			want: `{"password":"<redacted>","spec":{"s3":{"auth":{"accessKeyId":"AKIA","secretAccessKey":"<redacted>"}}}}`,
+		},
+		{
+			name: "snake case keys",
+			doc:  `{"client_secret":"s3cr3t"}`,
+			want: `{"client_secret":"<redacted>"}`,
+		},
+		{
+			name: "additional keys keep map keys",
+			doc:  `{"data":{"user":"admin","pass":"s3cr3t","old":null},"name":"creds"}`,
+			keys: []string{"data"},
+			want: `{"data":{"old":null,"pass":"<redacted>","user":"<redacted>"},"name":"creds"}`,
+		},
+		{
+			name: "lists",
+			doc:  `{"items":[{"clientSecret":"a"},{"clientSecret":"b"}]}`,
+			want: `{"items":[{"clientSecret":"<redacted>"},{"clientSecret":"<redacted>"}]}`,
+		},
+		{
+			name: "invalid document",
+			doc:  `{"password":`,
+			want: `"<redacted>"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := logging.Redact([]byte(test.doc), test.keys...)
+
+			assert.JSONEq(t, test.want, string(got))
+		})
+	}
+}
+
+func TestPatch(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &buf)
+
+	logging.Patch(ctx, "Secret", []byte(`{"data":{"key":"s3cr3t"}}`), "data")
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "debug", entries[0]["@level"])
+	assert.Equal(t, "Secret", entries[0]["kind"])
+	assert.JSONEq(t, `{"data":{"key":"<redacted>"}}`, entries[0]["patch"].(string))
+	assert.NotContains(t, buf.String(), "s3cr3t")
+}
+
+func TestObject(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &buf)
+
+	obj := struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}{Name: "test", Password: "s3cr3t"}
+	logging.Object(ctx, "ServiceAccount", obj)
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ServiceAccount", entries[0]["kind"])
+	assert.JSONEq(t, `{"name":"test","password":"<redacted>"}`, entries[0]["object"].(string))
+}
@@ -0,0 +1,52 @@
+package planmodifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MergeDefaults sets the map at allPath to the provider defaults merged with the
+// map configured at p. Configured values take precedence over the defaults.
+//
+// Like DefaultEnvironment, this must be called from the resource's ModifyPlan,
+// so that changed provider defaults show up in the plan.
+func MergeDefaults(ctx context.Context, p, allPath path.Path, defaults types.Map, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed.
+		return
+	}
+
+	var cfg types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &cfg)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if cfg.IsUnknown() || defaults.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	merged := make(map[string]attr.Value, len(defaults.Elements())+len(cfg.Elements()))
+	for k, v := range defaults.Elements() {
+		merged[k] = v
+	}
+	for k, v := range cfg.Elements() {
+		merged[k] = v
+	}
+	if len(merged) == 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, types.MapNull(types.StringType))...)
+		return
+	}
+
+	all, diags := types.MapValue(types.StringType, merged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, all)...)
+}
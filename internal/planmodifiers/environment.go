@@ -0,0 +1,54 @@
+package planmodifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultEnvironment fills the environment at the given path with the provider's
+// default environment when it is not configured. An explicitly configured
+// environment always wins.
+//
+// Attribute plan modifiers cannot access the provider configuration, so this
+// must be called from the resource's ModifyPlan. As the resolved environment
+// is stored in state, a changed default environment requires replacement.
+func DefaultEnvironment(ctx context.Context, p path.Path, defaultEnv types.String, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// The resource is being destroyed.
+		return
+	}
+
+	var cfgEnv types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &cfgEnv)...)
+	if resp.Diagnostics.HasError() || !cfgEnv.IsNull() {
+		return
+	}
+
+	switch {
+	case defaultEnv.IsUnknown():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, types.StringUnknown())...)
+		return
+	case defaultEnv.ValueString() == "":
+		resp.Diagnostics.AddAttributeError(
+			p,
+			"Missing Environment",
+			"The environment is not configured and the provider has no default_environment. "+
+				"Please set the environment or configure default_environment in the provider configuration.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, defaultEnv)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var stateEnv types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &stateEnv)...)
+	if !stateEnv.IsNull() && !stateEnv.Equal(defaultEnv) {
+		resp.RequiresReplace.Append(p)
+	}
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// StaticTokenSource returns a token source that always returns the given bearer token.
+func StaticTokenSource(token string) oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"})
+}
+
+// fileTokenLifetime is the lifetime of the tokens returned by FileTokenSource.
+// It is within the expiry delta of oauth2.ReuseTokenSource, so a wrapping token
+// source asks for a token on every request instead of caching it forever.
+const fileTokenLifetime = 10 * time.Second
+
+// FileTokenSource is a token source that reads a bearer token from a file.
+//
+// The file is re-read whenever its modification time or size changes, so
+// tokens rotated by an external process are picked up without restarting
+// the provider. The returned tokens expire shortly, so that the rotation is
+// also picked up through caching token sources.
+type FileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	tok     *oauth2.Token
+}
+
+// NewFileTokenSource returns a token source reading the token from the given path.
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{path: path}
+}
+
+// Token returns the token currently stored in the file.
+func (s *FileTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat token file %q: %w", s.path, err)
+	}
+	if s.tok != nil && fi.ModTime().Equal(s.modTime) && fi.Size() == s.size {
+		return s.token(), nil
+	}
+
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read token file %q: %w", s.path, err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return nil, fmt.Errorf("token file %q is empty", s.path)
+	}
+
+	s.modTime = fi.ModTime()
+	s.size = fi.Size()
+	s.tok = &oauth2.Token{AccessToken: token, TokenType: "Bearer"}
+	return s.token(), nil
+}
+
+// token returns a copy of the current token expiring after fileTokenLifetime.
+func (s *FileTokenSource) token() *oauth2.Token {
+	tok := *s.tok
+	tok.Expiry = time.Now().Add(fileTokenLifetime)
+	return &tok
+}
+
+// PasswordTokenSource is a token source using the OAuth2 password grant.
+//
+// The token is only requested on first use, so the provider can be configured
+// with credentials that are not known until apply, for example because they
+// are created by another resource.
+type PasswordTokenSource struct {
+	ctx      context.Context
+	cfg      *oauth2.Config
+	username string
+	password string
+
+	mu sync.Mutex
+	ts oauth2.TokenSource
+}
+
+// NewPasswordTokenSource returns a token source requesting a token with the given credentials.
+//
+// The context is used for all token requests, but its cancellation is ignored,
+// as the token source outlives the call configuring it.
+func NewPasswordTokenSource(ctx context.Context, cfg *oauth2.Config, username, password string) *PasswordTokenSource {
+	return &PasswordTokenSource{
+		ctx:      context.WithoutCancel(ctx),
+		cfg:      cfg,
+		username: username,
+		password: password,
+	}
+}
+
+// Token returns a valid token, requesting or refreshing it as needed.
+func (s *PasswordTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ts == nil {
+		tok, err := s.cfg.PasswordCredentialsToken(s.ctx, s.username, s.password)
+		if err != nil {
+			return nil, fmt.Errorf("could not request oauth token from %q: %w", s.cfg.Endpoint.TokenURL, err)
+		}
+		s.ts = s.cfg.TokenSource(s.ctx, tok)
+	}
+	return s.ts.Token()
+}
+
+// ErrorTokenSource returns a token source that always fails with the given error.
+func ErrorTokenSource(err error) oauth2.TokenSource {
+	return errorTokenSource{err: err}
+}
+
+type errorTokenSource struct {
+	err error
+}
+
+func (s errorTokenSource) Token() (*oauth2.Token, error) {
+	return nil, s.err
+}
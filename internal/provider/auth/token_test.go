@@ -0,0 +1,158 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestStaticTokenSource(t *testing.T) {
+	t.Parallel()
+
+	tok, err := auth.StaticTokenSource("my-token").Token()
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-token", tok.AccessToken)
+	assert.Equal(t, "Bearer", tok.TokenType)
+}
+
+func TestFileTokenSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	writeToken(t, path, "first\n", time.Now().Add(-time.Minute))
+
+	ts := auth.NewFileTokenSource(path)
+
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "first", tok.AccessToken)
+
+	writeToken(t, path, "second-token", time.Now())
+
+	tok, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "second-token", tok.AccessToken)
+}
+
+func TestFileTokenSource_ReuseTokenSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	writeToken(t, path, "first", time.Now().Add(-time.Minute))
+
+	ts := oauth2.ReuseTokenSource(nil, auth.NewFileTokenSource(path))
+
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "first", tok.AccessToken)
+	assert.False(t, tok.Expiry.IsZero())
+
+	writeToken(t, path, "second", time.Now())
+
+	tok, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "second", tok.AccessToken)
+}
+
+func TestFileTokenSource_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	ts := auth.NewFileTokenSource(filepath.Join(t.TempDir(), "missing"))
+
+	_, err := ts.Token()
+	require.Error(t, err)
+}
+
+func TestFileTokenSource_EmptyFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	writeToken(t, path, " \n", time.Now())
+
+	_, err := auth.NewFileTokenSource(path).Token()
+	require.Error(t, err)
+}
+
+func writeToken(t *testing.T, path, token string, modTime time.Time) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(token), 0o600)
+	require.NoError(t, err)
+	err = os.Chtimes(path, modTime, modTime)
+	require.NoError(t, err)
+}
+
+func TestPasswordTokenSource(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "user", r.FormValue("username"))
+		assert.Equal(t, "pass", r.FormValue("password"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"my-token","expires_in":3600}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	ts := auth.NewPasswordTokenSource(ctx, &oauth2.Config{
+		ClientID: "api",
+		Endpoint: oauth2.Endpoint{TokenURL: srv.URL},
+	}, "user", "pass")
+	// The token source must outlive the context it was configured with.
+	cancel()
+
+	assert.Equal(t, int64(0), calls.Load())
+
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "my-token", tok.AccessToken)
+
+	tok, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "my-token", tok.AccessToken)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestPasswordTokenSource_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+
+	ts := auth.NewPasswordTokenSource(t.Context(), &oauth2.Config{
+		ClientID: "api",
+		Endpoint: oauth2.Endpoint{TokenURL: srv.URL},
+	}, "user", "wrong")
+
+	_, err := ts.Token()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not request oauth token from")
+}
+
+func TestErrorTokenSource(t *testing.T) {
+	t.Parallel()
+
+	wantErr := errors.New("test error")
+
+	_, err := auth.ErrorTokenSource(wantErr).Token()
+
+	assert.ErrorIs(t, err, wantErr)
+}
@@ -2,16 +2,59 @@ package context
 
 import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/transport"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/oauth2"
 )
 
 // Context is the provider context.
 type Context struct {
 	ClientSet clientset.Interface
+
+	// TokenSource provides the bearer token used by the client set.
+	// The token is requested lazily on the first API call.
+	TokenSource oauth2.TokenSource
+
+	// Limiter bounds the concurrency and rate of all API requests.
+	// It is nil if the client set was not created by the provider.
+	Limiter *transport.Limiter
+
+	// Lists caches List results shared by data sources for the duration of
+	// the run. Resources invalidate the lists of the objects they change.
+	// It is nil if caching is disabled.
+	Lists *listcache.Cache
+
+	// DryRun is true if resources validate planned objects with a
+	// server-side dry run before they are applied.
+	DryRun bool
+
+	// ValidateReferences is true if resources look up the objects their
+	// containers and gateway policies refer to during plan.
+	ValidateReferences bool
+
+	// ConfigUnknown is true if the provider configuration depends on values
+	// that are only known after apply. API calls fail in that case, so
+	// API-backed plan-time work must be skipped.
+	ConfigUnknown bool
+
+	// DefaultEnvironment is the environment used by namespaced resources
+	// that do not configure one. It is unknown if the provider configuration
+	// is not yet known.
+	DefaultEnvironment types.String
+
+	// DefaultLabels and DefaultAnnotations are merged into the labels and
+	// annotations of every object. They are unknown if the provider
+	// configuration is not yet known.
+	DefaultLabels      types.Map
+	DefaultAnnotations types.Map
 }
 
 // NewContext creates a new context with the given client set.
 func NewContext(clientSet clientset.Interface) *Context {
 	return &Context{
-		ClientSet: clientSet,
+		ClientSet:          clientSet,
+		DefaultLabels:      types.MapNull(types.StringType),
+		DefaultAnnotations: types.MapNull(types.StringType),
 	}
 }
@@ -0,0 +1,116 @@
+// Package listcache provides a read-through cache of List results shared by
+// all data sources for the lifetime of the provider, which is a single Terraform run.
+// Resources that change listed objects invalidate the affected lists, so data
+// sources read during apply see the objects created earlier in the run.
+package listcache
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Key identifies a cached list.
+type Key struct {
+	// Kind is the kind of the listed objects, for example "Region".
+	Kind string
+	// Environment is the environment of namespaced objects.
+	// It is empty for cluster-scoped objects.
+	Environment string
+}
+
+func (k Key) String() string {
+	if k.Environment == "" {
+		return k.Kind
+	}
+	return k.Kind + "/" + k.Environment
+}
+
+// Cache is a read-through cache of List results.
+//
+// A nil Cache is valid and disables caching.
+type Cache struct {
+	group singleflight.Group
+
+	mu    sync.Mutex
+	lists map[Key]any
+	// gens counts the invalidations of each key, so that a list call started
+	// before an invalidation does not cache its outdated result.
+	gens map[Key]uint64
+}
+
+// New returns an empty cache.
+func New() *Cache {
+	return &Cache{
+		lists: map[Key]any{},
+		gens:  map[Key]uint64{},
+	}
+}
+
+// Invalidate removes the list cached under key, so that the next Get lists the objects again.
+// It must be called after an object of the key's kind and environment is created, changed or deleted.
+func (c *Cache) Invalidate(key Key) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.lists, key)
+	c.gens[key]++
+	c.group.Forget(key.String())
+}
+
+// Get returns the list cached under key, calling list on a miss.
+//
+// Concurrent calls for the same key share a single call of list. Errors are
+// not cached. The returned slice is a copy and may be reordered or filtered by
+// the caller, but its items are shared and must not be modified.
+func Get[T any](ctx context.Context, c *Cache, key Key, list func(context.Context) ([]T, error)) ([]T, error) {
+	if c == nil {
+		return list(ctx)
+	}
+
+	c.mu.Lock()
+	cached, ok := c.lists[key]
+	c.mu.Unlock()
+	if ok {
+		return slices.Clone(cached.([]T)), nil
+	}
+
+	v, err, _ := c.group.Do(key.String(), func() (any, error) {
+		c.mu.Lock()
+		gen := c.gens[key]
+		c.mu.Unlock()
+
+		// The call is shared, so it must not be canceled with the caller that started it.
+		items, err := list(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		if c.gens[key] == gen {
+			c.lists[key] = items
+		}
+		c.mu.Unlock()
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(v.([]T)), nil
+}
+
+// MatchLabels reports whether labels contain every key and value of selector.
+func MatchLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
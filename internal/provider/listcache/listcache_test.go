@@ -0,0 +1,195 @@
+package listcache_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet_CachesByKey(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	list := func(env string) func(context.Context) ([]string, error) {
+		return func(context.Context) ([]string, error) {
+			calls.Add(1)
+			return []string{env + "-b", env + "-a"}, nil
+		}
+	}
+	c := listcache.New()
+
+	got, err := listcache.Get(t.Context(), c, listcache.Key{Kind: "Region", Environment: "dflt"}, list("dflt"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dflt-b", "dflt-a"}, got)
+
+	got, err = listcache.Get(t.Context(), c, listcache.Key{Kind: "Region", Environment: "dflt"}, list("dflt"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dflt-b", "dflt-a"}, got)
+
+	got, err = listcache.Get(t.Context(), c, listcache.Key{Kind: "Region", Environment: "prod"}, list("prod"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-b", "prod-a"}, got)
+
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestGet_ReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	c := listcache.New()
+	key := listcache.Key{Kind: "Location"}
+	list := func(context.Context) ([]string, error) { return []string{"b", "a"}, nil }
+
+	got, err := listcache.Get(t.Context(), c, key, list)
+	require.NoError(t, err)
+	got[0] = "changed"
+
+	got, err = listcache.Get(t.Context(), c, key, list)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, got)
+}
+
+func TestGet_DeduplicatesConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	list := func(context.Context) ([]string, error) {
+		calls.Add(1)
+		<-release
+		return []string{"a"}, nil
+	}
+	c := listcache.New()
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			got, err := listcache.Get(t.Context(), c, listcache.Key{Kind: "Protocol"}, list)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"a"}, got)
+		})
+	}
+	for calls.Load() == 0 {
+		// Wait for the first call to start.
+		runtime.Gosched()
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestGet_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	list := func(context.Context) ([]string, error) {
+		if calls.Add(1) == 1 {
+			return nil, errors.New("boom")
+		}
+		return []string{"a"}, nil
+	}
+	c := listcache.New()
+
+	_, err := listcache.Get(t.Context(), c, listcache.Key{Kind: "Environment"}, list)
+	require.Error(t, err)
+
+	got, err := listcache.Get(t.Context(), c, listcache.Key{Kind: "Environment"}, list)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, got)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestGet_NilCacheDisablesCaching(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	list := func(context.Context) ([]string, error) {
+		calls.Add(1)
+		return []string{"a"}, nil
+	}
+
+	for range 2 {
+		_, err := listcache.Get[string](t.Context(), nil, listcache.Key{Kind: "Location"}, list)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	list := func(context.Context) ([]string, error) {
+		calls.Add(1)
+		return []string{"a"}, nil
+	}
+	c := listcache.New()
+	key := listcache.Key{Kind: "Region", Environment: "dflt"}
+	otherKey := listcache.Key{Kind: "Region", Environment: "prod"}
+
+	_, err := listcache.Get(t.Context(), c, key, list)
+	require.NoError(t, err)
+	_, err = listcache.Get(t.Context(), c, otherKey, list)
+	require.NoError(t, err)
+
+	c.Invalidate(key)
+
+	_, err = listcache.Get(t.Context(), c, key, list)
+	require.NoError(t, err)
+	_, err = listcache.Get(t.Context(), c, otherKey, list)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestCache_InvalidateDuringList(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	c := listcache.New()
+	key := listcache.Key{Kind: "Environment"}
+	list := func(context.Context) ([]string, error) {
+		if calls.Add(1) == 1 {
+			// The object is changed while it is listed.
+			c.Invalidate(key)
+			return []string{"old"}, nil
+		}
+		return []string{"new"}, nil
+	}
+
+	got, err := listcache.Get(t.Context(), c, key, list)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old"}, got)
+
+	got, err = listcache.Get(t.Context(), c, key, list)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new"}, got)
+}
+
+func TestCache_InvalidateNilCache(t *testing.T) {
+	t.Parallel()
+
+	var c *listcache.Cache
+
+	assert.NotPanics(t, func() { c.Invalidate(listcache.Key{Kind: "Region"}) })
+}
+
+func TestMatchLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"tier": "prod", "team": "ops"}
+
+	assert.True(t, listcache.MatchLabels(labels, nil))
+	assert.True(t, listcache.MatchLabels(labels, map[string]string{"tier": "prod"}))
+	assert.False(t, listcache.MatchLabels(labels, map[string]string{"tier": "dev"}))
+	assert.False(t, listcache.MatchLabels(nil, map[string]string{"tier": "prod"}))
+}
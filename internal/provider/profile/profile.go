@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultName is the name of the profile used when none is selected.
+const DefaultName = "default"
+
+// Profile holds the connection settings of a single GameFabric installation.
+type Profile struct {
+	Host           string `yaml:"host"`
+	CustomerID     string `yaml:"customer_id"`
+	ServiceAccount string `yaml:"service_account"`
+	Password       string `yaml:"password"`
+	Token          string `yaml:"token"`
+	TokenFile      string `yaml:"token_file"`
+}
+
+// Config is the provider configuration file, holding one profile per installation.
+//
+// For example:
+//
+//	current_profile: prod
+//	profiles:
+//	  prod:
+//	    customer_id: acme
+//	    service_account: terraform@ec.nitrado.systems
+//	  staging:
+//	    host: acme-staging.gamefabric.dev
+//	    token_file: ~/.gamefabric/staging-token
+type Config struct {
+	CurrentProfile string             `yaml:"current_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// NotFoundError is returned by Load if the config file has no profile with the selected name.
+type NotFoundError struct {
+	Name string
+	Path string
+	// Available is the comma separated list of profiles in the config file.
+	Available string
+}
+
+// Error returns the error message.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("profile %q not found in config file %q (available profiles: %s)", e.Name, e.Path, e.Available)
+}
+
+// Load reads the config file at path and returns the profile with the given name.
+//
+// If name is empty, the config file's current_profile is used, falling back to
+// the profile named "default". A relative token_file is resolved against the
+// directory of the config file.
+func Load(path, name string) (Profile, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("could not read config file %q: %w", path, err)
+	}
+
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err = dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Profile{}, fmt.Errorf("could not parse config file %q: %w", path, err)
+	}
+
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	if name == "" {
+		name = DefaultName
+	}
+
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return Profile{}, &NotFoundError{Name: name, Path: path, Available: availableProfiles(cfg.Profiles)}
+	}
+
+	if p.TokenFile != "" {
+		if p.TokenFile, err = expandHome(p.TokenFile); err != nil {
+			return Profile{}, fmt.Errorf("profile %q in config file %q: %w", name, path, err)
+		}
+		if !filepath.IsAbs(p.TokenFile) {
+			p.TokenFile = filepath.Join(filepath.Dir(path), p.TokenFile)
+		}
+	}
+	return p, nil
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not expand %q: %w", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+func availableProfiles(profiles map[string]Profile) string {
+	if len(profiles) == 0 {
+		return "none"
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,115 @@
+package profile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+current_profile: prod
+profiles:
+  prod:
+    customer_id: acme
+    service_account: terraform@ec.nitrado.systems
+    password: secret
+  staging:
+    host: acme-staging.gamefabric.dev
+    token_file: staging-token
+`
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, testConfig)
+
+	got, err := profile.Load(path, "staging")
+
+	require.NoError(t, err)
+	assert.Equal(t, profile.Profile{
+		Host:      "acme-staging.gamefabric.dev",
+		TokenFile: filepath.Join(filepath.Dir(path), "staging-token"),
+	}, got)
+}
+
+func TestLoad_UsesCurrentProfile(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, testConfig)
+
+	got, err := profile.Load(path, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, profile.Profile{
+		CustomerID:     "acme",
+		ServiceAccount: "terraform@ec.nitrado.systems",
+		Password:       "secret",
+	}, got)
+}
+
+func TestLoad_UsesDefaultProfile(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, `
+profiles:
+  default:
+    token: my-token
+`)
+
+	got, err := profile.Load(path, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, profile.Profile{Token: "my-token"}, got)
+}
+
+func TestLoad_MissingProfile(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, testConfig)
+
+	_, err := profile.Load(path, "dev")
+
+	require.Error(t, err)
+	var notFound *profile.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, `profile "dev" not found in config file "`+path+`" (available profiles: prod, staging)`, err.Error())
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing.yaml")
+
+	_, err := profile.Load(path, "prod")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `could not read config file "`+path+`"`)
+}
+
+func TestLoad_UnknownField(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t, `
+profiles:
+  prod:
+    hostname: acme.gamefabric.dev
+`)
+
+	_, err := profile.Load(path, "prod")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `could not parse config file "`+path+`"`)
+	assert.Contains(t, err.Error(), "hostname")
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
@@ -2,13 +2,18 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	dsauthentication "github.com/gamefabric/terraform-provider-gamefabric/internal/datasource/authentication"
 	dscontainer "github.com/gamefabric/terraform-provider-gamefabric/internal/datasource/container"
 	dscore "github.com/gamefabric/terraform-provider-gamefabric/internal/datasource/core"
@@ -17,7 +22,14 @@ import (
 	dsprovisioning "github.com/gamefabric/terraform-provider-gamefabric/internal/datasource/provisioning"
 	dsrbac "github.com/gamefabric/terraform-provider-gamefabric/internal/datasource/rbac"
 	dsstorage "github.com/gamefabric/terraform-provider-gamefabric/internal/datasource/storage"
+	ephauthentication "github.com/gamefabric/terraform-provider-gamefabric/internal/ephemeral/authentication"
+	ephprovisioning "github.com/gamefabric/terraform-provider-gamefabric/internal/ephemeral/provisioning"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/functions"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/auth"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/profile"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/transport"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/armada"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/audit"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/authentication"
@@ -29,30 +41,81 @@ import (
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/protection"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/rbac"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/storage"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"golang.org/x/oauth2"
 )
 
-var _ provider.Provider = &Provider{}
+var (
+	_ provider.Provider                       = &Provider{}
+	_ provider.ProviderWithListResources      = &Provider{}
+	_ provider.ProviderWithEphemeralResources = &Provider{}
+	_ provider.ProviderWithFunctions          = &Provider{}
+)
 
 const (
 	envHost           = "GAMEFABRIC_HOST"
 	envCustomerID     = "GAMEFABRIC_CUSTOMER_ID"
 	envServiceAccount = "GAMEFABRIC_SERVICE_ACCOUNT"
 	envPassword       = "GAMEFABRIC_PASSWORD"
+	envToken          = "GAMEFABRIC_TOKEN"
+	envTokenFile      = "GAMEFABRIC_TOKEN_FILE"
+	envConfigPath     = "GAMEFABRIC_CONFIG_PATH"
+	envProfile        = "GAMEFABRIC_PROFILE"
+)
+
+const (
+	defaultTimeout         = 10 * time.Second
+	defaultMaxRetries      = 3
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 30 * time.Second
 )
 
 // providerModel is the provider configuration model.
 type providerModel struct {
-	Host           types.String `tfsdk:"host"`
-	CustomerID     types.String `tfsdk:"customer_id"`
-	ServiceAccount types.String `tfsdk:"service_account"`
-	Password       types.String `tfsdk:"password"`
+	Host               types.String  `tfsdk:"host"`
+	CustomerID         types.String  `tfsdk:"customer_id"`
+	ServiceAccount     types.String  `tfsdk:"service_account"`
+	Password           types.String  `tfsdk:"password"`
+	Token              types.String  `tfsdk:"token"`
+	TokenFile          types.String  `tfsdk:"token_file"`
+	ConfigPath         types.String  `tfsdk:"config_path"`
+	Profile            types.String  `tfsdk:"profile"`
+	CACertPEM          types.String  `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String  `tfsdk:"ca_cert_file"`
+	ProxyURL           types.String  `tfsdk:"proxy_url"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_tls_verify"`
+	MaxConcurrent      types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
+	DisableListCache   types.Bool    `tfsdk:"disable_list_cache"`
+	DisableDryRun      types.Bool    `tfsdk:"disable_dry_run"`
+	ValidateReferences types.Bool    `tfsdk:"validate_references"`
+	DefaultEnvironment types.String  `tfsdk:"default_environment"`
+	DefaultLabels      types.Map     `tfsdk:"default_labels"`
+	DefaultAnnotations types.Map     `tfsdk:"default_annotations"`
+	HTTP               *httpModel    `tfsdk:"http"`
+}
+
+// httpModel is the HTTP client configuration model.
+type httpModel struct {
+	Timeout         types.String `tfsdk:"timeout"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff types.String `tfsdk:"retry_max_backoff"`
 }
 
 // Provider is the GameFabric provider implementation.
@@ -93,8 +156,8 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 		MarkdownDescription: "GameFabric provider is used to interact, provision and manage your GameFabric resources.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description:         "The GameFabric API host for example: 'example.gamefabric.dev'.",
-				MarkdownDescription: "The GameFabric API host for example: `example.gamefabric.dev`.",
+				Description:         "The GameFabric API host for example: 'example.gamefabric.dev'. A full URL such as 'http://localhost:8080' can be given to use a different scheme or port.",
+				MarkdownDescription: "The GameFabric API host for example: `example.gamefabric.dev`. A full URL such as `http://localhost:8080` can be given to use a different scheme or port.",
 				Optional:            true,
 			},
 			"customer_id": schema.StringAttribute{
@@ -113,38 +176,181 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token": schema.StringAttribute{
+				Description:         "A bearer token used to authenticate against the GameFabric API. Conflicts with 'service_account', 'password' and 'token_file'.",
+				MarkdownDescription: "A bearer token used to authenticate against the GameFabric API. Conflicts with `service_account`, `password` and `token_file`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"token_file": schema.StringAttribute{
+				Description:         "The path to a file containing a bearer token. The file is re-read when it changes, allowing the token to be rotated. Conflicts with 'service_account', 'password' and 'token'.",
+				MarkdownDescription: "The path to a file containing a bearer token. The file is re-read when it changes, allowing the token to be rotated. Conflicts with `service_account`, `password` and `token`.",
+				Optional:            true,
+			},
+			"config_path": schema.StringAttribute{
+				Description:         "The path to a YAML config file holding named profiles with the host, customer ID and credentials of GameFabric installations.",
+				MarkdownDescription: "The path to a YAML config file holding named profiles with the host, customer ID and credentials of GameFabric installations.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				Description:         "The name of the profile to use from the config file. Defaults to the config file's 'current_profile', or 'default'. Settings configured in the provider configuration or environment variables take precedence over the profile.",
+				MarkdownDescription: "The name of the profile to use from the config file. Defaults to the config file's `current_profile`, or `default`. Settings configured in the provider configuration or environment variables take precedence over the profile.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description:         "PEM encoded CA certificates trusted in addition to the system certificates when connecting to the GameFabric API. Conflicts with 'ca_cert_file'.",
+				MarkdownDescription: "PEM encoded CA certificates trusted in addition to the system certificates when connecting to the GameFabric API. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description:         "The path to a file containing PEM encoded CA certificates trusted in addition to the system certificates when connecting to the GameFabric API. Conflicts with 'ca_cert_pem'.",
+				MarkdownDescription: "The path to a file containing PEM encoded CA certificates trusted in addition to the system certificates when connecting to the GameFabric API. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description:         "The URL of the proxy used to connect to the GameFabric API, for example 'http://proxy.example.com:3128'. Defaults to the proxy configured in the HTTPS_PROXY environment variable.",
+				MarkdownDescription: "The URL of the proxy used to connect to the GameFabric API, for example `http://proxy.example.com:3128`. Defaults to the proxy configured in the `HTTPS_PROXY` environment variable.",
+				Optional:            true,
+			},
+			"insecure_skip_tls_verify": schema.BoolAttribute{
+				Description:         "Whether to skip the verification of the GameFabric API's TLS certificate. This is insecure and should only be used for testing.",
+				MarkdownDescription: "Whether to skip the verification of the GameFabric API's TLS certificate. This is insecure and should only be used for testing.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description:         "The maximum number of concurrent requests to the GameFabric API, shared by all resources and data sources. Defaults to no limit.",
+				MarkdownDescription: "The maximum number of concurrent requests to the GameFabric API, shared by all resources and data sources. Defaults to no limit.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description:         "The maximum sustained rate of requests to the GameFabric API, shared by all resources and data sources. Set to 0 or leave unset for no limit.",
+				MarkdownDescription: "The maximum sustained rate of requests to the GameFabric API, shared by all resources and data sources. Set to `0` or leave unset for no limit.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"disable_list_cache": schema.BoolAttribute{
+				Description:         "Whether to disable the caching of list lookups. By default, data sources listing the same kind of objects in the same environment share a single request per Terraform run.",
+				MarkdownDescription: "Whether to disable the caching of list lookups. By default, data sources listing the same kind of objects in the same environment share a single request per Terraform run.",
+				Optional:            true,
+			},
+			"disable_dry_run": schema.BoolAttribute{
+				Description:         "Whether to disable the validation of planned objects with a server-side dry run. By default, planned armadas, armada sets, formations, vessels, regions and secrets are sent to the API without being persisted, so that rejections are reported during plan.",
+				MarkdownDescription: "Whether to disable the validation of planned objects with a server-side dry run. By default, planned armadas, armada sets, formations, vessels, regions and secrets are sent to the API without being persisted, so that rejections are reported during plan.",
+				Optional:            true,
+			},
+			"validate_references": schema.BoolAttribute{
+				Description:         "Whether to look up the config files, secrets and gateway policies referenced by armadas, armada sets, formations and vessels during plan, so that missing objects and secret keys are reported as warnings before apply. Defaults to false.",
+				MarkdownDescription: "Whether to look up the config files, secrets and gateway policies referenced by armadas, armada sets, formations and vessels during plan, so that missing objects and secret keys are reported as warnings before apply. Defaults to `false`.",
+				Optional:            true,
+			},
+			"default_environment": schema.StringAttribute{
+				Description:         "The environment used by namespaced resources that do not configure an environment.",
+				MarkdownDescription: "The environment used by namespaced resources that do not configure an `environment`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.EnvironmentValidator{},
+				},
+			},
+			"default_labels": schema.MapAttribute{
+				Description:         "Labels added to every object managed by the provider. Labels configured on a resource take precedence.",
+				MarkdownDescription: "Labels added to every object managed by the provider. Labels configured on a resource take precedence.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					validators.LabelsValidator{},
+				},
+			},
+			"default_annotations": schema.MapAttribute{
+				Description:         "Annotations added to every object managed by the provider. Annotations configured on a resource take precedence.",
+				MarkdownDescription: "Annotations added to every object managed by the provider. Annotations configured on a resource take precedence.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					validators.AnnotationsValidator{},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"http": schema.SingleNestedBlock{
+				Description:         "Configures the HTTP client used to talk to the GameFabric API.",
+				MarkdownDescription: "Configures the HTTP client used to talk to the GameFabric API.",
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.StringAttribute{
+						Description:         "The timeout of a single API request attempt, for example '30s'. Defaults to '10s'.",
+						MarkdownDescription: "The timeout of a single API request attempt, for example `30s`. Defaults to `10s`.",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.DurationValidator{},
+						},
+					},
+					"max_retries": schema.Int64Attribute{
+						Description:         "The maximum number of retries of an idempotent request on rate limiting, server errors and connection resets. Set to 0 to disable retries. Defaults to 3.",
+						MarkdownDescription: "The maximum number of retries of an idempotent request on rate limiting, server errors and connection resets. Set to `0` to disable retries. Defaults to `3`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"retry_min_backoff": schema.StringAttribute{
+						Description:         "The backoff before the first retry. It grows exponentially with each retry. Defaults to '500ms'.",
+						MarkdownDescription: "The backoff before the first retry. It grows exponentially with each retry. Defaults to `500ms`.",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.DurationValidator{},
+						},
+					},
+					"retry_max_backoff": schema.StringAttribute{
+						Description:         "The maximum backoff between retries, which also bounds a delay requested by a 'Retry-After' header sent by the API. Must not be less than retry_min_backoff. Defaults to '30s'.",
+						MarkdownDescription: "The maximum backoff between retries, which also bounds a delay requested by a `Retry-After` header sent by the API. Must not be less than `retry_min_backoff`. Defaults to `30s`.",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.DurationValidator{},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 // Configure prepares the provider for data sources and resources.
 func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	cfg := &providerModel{}
+	if !req.Config.Raw.IsNull() {
+		diags := req.Config.Get(ctx, &cfg)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if p.clientSet != nil {
 		// Use the pre-configured client set (for testing).
-		provCtx := provcontext.NewContext(p.clientSet)
+		provCtx := newProviderContext(p.clientSet, cfg)
 		resp.DataSourceData = provCtx
 		resp.ResourceData = provCtx
+		resp.ListResourceData = provCtx
+		resp.EphemeralResourceData = provCtx
 		return
 	}
 
-	cfg := &providerModel{}
-	diags := req.Config.Get(ctx, &cfg)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	if hasUnknownConnection(cfg) {
+		configureUnknown(req, resp, cfg)
 		return
 	}
 
-	if env := os.Getenv(envHost); cfg.Host.ValueString() == "" && env != "" {
-		cfg.Host = types.StringValue(env)
-	}
-	if env := os.Getenv(envCustomerID); cfg.CustomerID.ValueString() == "" && env != "" {
-		cfg.CustomerID = types.StringValue(env)
-	}
-	if env := os.Getenv(envServiceAccount); cfg.ServiceAccount.ValueString() == "" && env != "" {
-		cfg.ServiceAccount = types.StringValue(env)
-	}
-	if env := os.Getenv(envPassword); cfg.Password.ValueString() == "" && env != "" {
-		cfg.Password = types.StringValue(env)
+	applyEnv(cfg)
+	resp.Diagnostics.Append(applyProfile(cfg)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	if cfg.CustomerID.ValueString() != "" && cfg.Host.ValueString() == "" {
 		cfg.Host = types.StringValue(cfg.CustomerID.ValueString() + ".gamefabric.dev")
@@ -155,8 +361,12 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	var err error
-	p.clientSet, err = newClientSet(ctx, cfg.Host.ValueString(), cfg.ServiceAccount.ValueString(), cfg.Password.ValueString())
+	var (
+		ts      oauth2.TokenSource
+		err     error
+		limiter = newLimiter(cfg)
+	)
+	p.clientSet, ts, err = newClientSet(ctx, cfg, limiter)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create GameFabric client",
@@ -165,9 +375,72 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	provCtx := provcontext.NewContext(p.clientSet)
+	provCtx := newProviderContext(p.clientSet, cfg)
+	provCtx.TokenSource = ts
+	provCtx.Limiter = limiter
 	resp.DataSourceData = provCtx
 	resp.ResourceData = provCtx
+	resp.ListResourceData = provCtx
+	resp.EphemeralResourceData = provCtx
+}
+
+// configureUnknown configures the provider when its configuration depends on
+// values that are only known after apply, for example credentials created by
+// another resource. Every API call fails until the configuration is known.
+func configureUnknown(req provider.ConfigureRequest, resp *provider.ConfigureResponse, cfg *providerModel) {
+	if req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+		return
+	}
+
+	cs, err := newUnknownClientSet()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create GameFabric client",
+			err.Error(),
+		)
+		return
+	}
+	provCtx := newProviderContext(cs, cfg)
+	provCtx.TokenSource = auth.ErrorTokenSource(errConfigUnknown)
+	provCtx.ConfigUnknown = true
+	// Every API call fails, so there is nothing to validate against.
+	provCtx.DryRun = false
+	resp.DataSourceData = provCtx
+	resp.ResourceData = provCtx
+	resp.ListResourceData = provCtx
+	resp.EphemeralResourceData = provCtx
+}
+
+// applyEnv fills the connection settings not set in the configuration from the environment.
+//
+// Credentials are only taken from the environment if they do not conflict with
+// the configured ones, so the configuration takes precedence.
+func applyEnv(cfg *providerModel) {
+	if env := os.Getenv(envHost); cfg.Host.ValueString() == "" && env != "" {
+		cfg.Host = types.StringValue(env)
+	}
+	if env := os.Getenv(envCustomerID); cfg.CustomerID.ValueString() == "" && env != "" {
+		cfg.CustomerID = types.StringValue(env)
+	}
+	switch {
+	case cfg.Token.ValueString() != "" || cfg.TokenFile.ValueString() != "":
+		// A token is configured, it takes precedence over any credentials in the environment.
+	case cfg.ServiceAccount.ValueString() != "" || cfg.Password.ValueString() != "":
+		setIfEmpty(&cfg.ServiceAccount, os.Getenv(envServiceAccount))
+		setIfEmpty(&cfg.Password, os.Getenv(envPassword))
+	default:
+		setIfEmpty(&cfg.ServiceAccount, os.Getenv(envServiceAccount))
+		setIfEmpty(&cfg.Password, os.Getenv(envPassword))
+		setIfEmpty(&cfg.Token, os.Getenv(envToken))
+		setIfEmpty(&cfg.TokenFile, os.Getenv(envTokenFile))
+	}
+	if env := os.Getenv(envConfigPath); cfg.ConfigPath.ValueString() == "" && env != "" {
+		cfg.ConfigPath = types.StringValue(env)
+	}
+	if env := os.Getenv(envProfile); cfg.Profile.ValueString() == "" && env != "" {
+		cfg.Profile = types.StringValue(env)
+	}
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -204,6 +477,25 @@ func (p *Provider) DataSources(_ context.Context) []func() datasource.DataSource
 	}
 }
 
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *Provider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephauthentication.NewServiceAccountPassword,
+		ephprovisioning.NewAllocatorToken,
+		ephprovisioning.NewPingDiscoveryToken,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *Provider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewDynamicBufferThresholds,
+		functions.NewObjectID,
+		functions.NewQuantityCompare,
+		functions.NewSplitObjectID,
+	}
+}
+
 // Resources defines the resources implemented in the provider.
 func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
@@ -232,13 +524,158 @@ func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
 	}
 }
 
-func newClientSet(ctx context.Context, host, user, pass string) (clientset.Interface, error) {
-	apiURL, err := url.Parse("https://" + host)
+// ListResources defines the list resources implemented in the provider.
+func (p *Provider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		armada.NewArmadaList,
+		armada.NewArmadaSetList,
+		container.NewBranchList,
+		core.NewConfigFileList,
+		core.NewRegionList,
+		core.NewSecretList,
+		formation.NewFormationList,
+		formation.NewVesselList,
+		protection.NewGatewayPolicyList,
+	}
+}
+
+func newProviderContext(cs clientset.Interface, cfg *providerModel) *provcontext.Context {
+	provCtx := provcontext.NewContext(cs)
+	provCtx.DefaultEnvironment = cfg.DefaultEnvironment
+	provCtx.DryRun = !cfg.DisableDryRun.ValueBool()
+	provCtx.ValidateReferences = cfg.ValidateReferences.ValueBool()
+	if !cfg.DisableListCache.ValueBool() {
+		provCtx.Lists = listcache.New()
+	}
+	if !cfg.DefaultLabels.IsNull() {
+		provCtx.DefaultLabels = cfg.DefaultLabels
+	}
+	if !cfg.DefaultAnnotations.IsNull() {
+		provCtx.DefaultAnnotations = cfg.DefaultAnnotations
+	}
+	return provCtx
+}
+
+// newClientSet creates the client set and the token source used to authenticate it.
+// No token is requested until the first API call. Every request, including the
+// token request, waits for the shared limiter.
+func newClientSet(ctx context.Context, cfg *providerModel, limiter *transport.Limiter) (clientset.Interface, oauth2.TokenSource, error) {
+	apiURL, err := newAPIURL(cfg.Host.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseOpts, err := newBaseOptions(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	base, err := transport.NewBase(baseOpts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not configure the HTTP transport: %w", err)
+	}
+
+	retryOpts, err := newRetryOptions(cfg.HTTP)
+	if err != nil {
+		return nil, nil, err
+	}
+	rt := transport.NewRetry(transport.NewLimit(transport.NewLogging(base), limiter), retryOpts)
+
+	// The token request must use the same transport as the API requests.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: rt})
+	ts := newTokenSource(ctx, apiURL, cfg)
+
+	restCfg := rest.Config{
+		BaseURL:           apiURL.String(),
+		Transport:         rt,
+		BearerTokenSource: ts,
+	}
+
+	cs, err := clientset.New(restCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create the gamefabric client: %w", err)
+	}
+
+	return cs, ts, nil
+}
+
+// errConfigUnknown is returned by API calls made while the provider configuration is unknown.
+var errConfigUnknown = errors.New("the provider configuration is not known yet, it depends on values that are only known after apply")
+
+// newUnknownClientSet returns a client set failing every request,
+// used while the provider configuration is unknown.
+func newUnknownClientSet() (clientset.Interface, error) {
+	cs, err := clientset.New(rest.Config{
+		BaseURL:           "https://gamefabric.invalid",
+		BearerTokenSource: auth.ErrorTokenSource(errConfigUnknown),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create the gamefabric client: %w", err)
+	}
+	return cs, nil
+}
+
+// hasUnknownConnection reports whether any setting needed to connect to the API is unknown.
+func hasUnknownConnection(cfg *providerModel) bool {
+	vals := []attr.Value{
+		cfg.Host, cfg.CustomerID, cfg.ServiceAccount, cfg.Password, cfg.Token, cfg.TokenFile,
+		cfg.ConfigPath, cfg.Profile, cfg.CACertPEM, cfg.CACertFile, cfg.ProxyURL, cfg.InsecureSkipVerify,
+	}
+	if cfg.HTTP != nil {
+		vals = append(vals, cfg.HTTP.Timeout, cfg.HTTP.MaxRetries, cfg.HTTP.RetryMinBackoff, cfg.HTTP.RetryMaxBackoff)
+	}
+	return slices.ContainsFunc(vals, attr.Value.IsUnknown)
+}
+
+// newAPIURL returns the API URL for the given host, which is either
+// a host name or a full URL with a http or https scheme.
+func newAPIURL(host string) (*url.URL, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	apiURL, err := url.Parse(host)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse API URL %q: %w", host, err)
 	}
+	if apiURL.Scheme != "http" && apiURL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q in API URL %q, must be http or https", apiURL.Scheme, host)
+	}
+	if apiURL.Host == "" {
+		return nil, fmt.Errorf("missing host in API URL %q", host)
+	}
+	return apiURL, nil
+}
+
+func newBaseOptions(cfg *providerModel) (transport.BaseOptions, error) {
+	opts := transport.BaseOptions{
+		CACertPEM:          []byte(cfg.CACertPEM.ValueString()),
+		InsecureSkipVerify: cfg.InsecureSkipVerify.ValueBool(),
+	}
+	if file := cfg.CACertFile.ValueString(); file != "" {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return transport.BaseOptions{}, fmt.Errorf("could not read CA certificate file %q: %w", file, err)
+		}
+		opts.CACertPEM = b
+	}
+	if proxy := cfg.ProxyURL.ValueString(); proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil {
+			return transport.BaseOptions{}, fmt.Errorf("could not parse proxy URL %q: %w", proxy, err)
+		}
+		opts.ProxyURL = u
+	}
+	return opts, nil
+}
 
-	oauth := oauth2.Config{
+func newTokenSource(ctx context.Context, apiURL *url.URL, cfg *providerModel) oauth2.TokenSource {
+	switch {
+	case cfg.Token.ValueString() != "":
+		return auth.StaticTokenSource(cfg.Token.ValueString())
+	case cfg.TokenFile.ValueString() != "":
+		return auth.NewFileTokenSource(cfg.TokenFile.ValueString())
+	}
+
+	oauth := &oauth2.Config{
 		ClientID: "api",
 		Scopes:   []string{"openid", "email", "profile", "offline_access"},
 		Endpoint: oauth2.Endpoint{
@@ -246,23 +683,117 @@ func newClientSet(ctx context.Context, host, user, pass string) (clientset.Inter
 			TokenURL:  apiURL.JoinPath("/auth/token").String(),
 		},
 	}
-	tok, err := oauth.PasswordCredentialsToken(ctx, user, pass)
-	if err != nil {
-		return nil, fmt.Errorf("could not request oauth token from %q: %w", host, err)
+	return auth.NewPasswordTokenSource(ctx, oauth, cfg.ServiceAccount.ValueString(), cfg.Password.ValueString())
+}
+
+func newLimiter(cfg *providerModel) *transport.Limiter {
+	var opts transport.LimitOptions
+	if conv.IsKnown(cfg.MaxConcurrent) {
+		opts.MaxConcurrent = int(cfg.MaxConcurrent.ValueInt64())
+	}
+	if conv.IsKnown(cfg.RequestsPerSecond) {
+		opts.RequestsPerSecond = cfg.RequestsPerSecond.ValueFloat64()
 	}
+	return transport.NewLimiter(opts)
+}
 
-	restCfg := rest.Config{
-		BaseURL:           apiURL.String(),
-		Timeout:           10 * time.Second,
-		BearerTokenSource: oauth.TokenSource(ctx, tok),
+func newRetryOptions(cfg *httpModel) (transport.RetryOptions, error) {
+	opts := transport.RetryOptions{
+		Timeout:    defaultTimeout,
+		MaxRetries: defaultMaxRetries,
+		MinBackoff: defaultRetryMinBackoff,
+		MaxBackoff: defaultRetryMaxBackoff,
+	}
+	if cfg == nil {
+		return opts, nil
 	}
 
-	cs, err := clientset.New(restCfg)
+	if conv.IsKnown(cfg.MaxRetries) {
+		opts.MaxRetries = int(cfg.MaxRetries.ValueInt64())
+	}
+	for _, d := range []struct {
+		name string
+		val  types.String
+		dst  *time.Duration
+	}{
+		{name: "timeout", val: cfg.Timeout, dst: &opts.Timeout},
+		{name: "retry_min_backoff", val: cfg.RetryMinBackoff, dst: &opts.MinBackoff},
+		{name: "retry_max_backoff", val: cfg.RetryMaxBackoff, dst: &opts.MaxBackoff},
+	} {
+		if !conv.IsKnown(d.val) {
+			continue
+		}
+		v, err := time.ParseDuration(d.val.ValueString())
+		if err != nil {
+			return transport.RetryOptions{}, fmt.Errorf("could not parse http.%s: %w", d.name, err)
+		}
+		*d.dst = v
+	}
+	if opts.MinBackoff > opts.MaxBackoff {
+		return transport.RetryOptions{}, fmt.Errorf("http.retry_min_backoff %s must not be greater than http.retry_max_backoff %s",
+			opts.MinBackoff, opts.MaxBackoff)
+	}
+	return opts, nil
+}
+
+// applyProfile fills the settings not configured in the provider configuration
+// or environment variables from the selected profile of the config file.
+//
+// The host and customer ID are only taken from the profile if neither is
+// configured, and credentials only if they do not conflict with the configured ones.
+func applyProfile(cfg *providerModel) []diag.Diagnostic {
+	if cfg.ConfigPath.ValueString() == "" {
+		if cfg.Profile.ValueString() != "" {
+			return []diag.Diagnostic{diag.NewAttributeErrorDiagnostic(
+				path.Root("config_path"),
+				"Missing Config Path",
+				fmt.Sprintf("The provider cannot load the profile %q as there is no config file configured. "+
+					"Please set the config_path value in the provider configuration or use the "+envConfigPath+" environment variable.",
+					cfg.Profile.ValueString()),
+			)}
+		}
+		return nil
+	}
+
+	prof, err := profile.Load(cfg.ConfigPath.ValueString(), cfg.Profile.ValueString())
 	if err != nil {
-		return nil, fmt.Errorf("could not create the gamefabric client: %w", err)
+		// Only a missing profile is caused by the profile setting, any other error by the config file.
+		attr := path.Root("config_path")
+		var notFound *profile.NotFoundError
+		if errors.As(err, &notFound) {
+			attr = path.Root("profile")
+		}
+		return []diag.Diagnostic{diag.NewAttributeErrorDiagnostic(
+			attr,
+			"Invalid Profile",
+			"The provider cannot load its profile: "+err.Error(),
+		)}
 	}
 
-	return cs, nil
+	if cfg.Host.ValueString() == "" && cfg.CustomerID.ValueString() == "" {
+		setIfEmpty(&cfg.Host, prof.Host)
+		setIfEmpty(&cfg.CustomerID, prof.CustomerID)
+	}
+
+	switch {
+	case cfg.Token.ValueString() != "" || cfg.TokenFile.ValueString() != "":
+		// A token is configured, any credentials of the profile would conflict.
+	case cfg.ServiceAccount.ValueString() != "" || cfg.Password.ValueString() != "":
+		setIfEmpty(&cfg.ServiceAccount, prof.ServiceAccount)
+		setIfEmpty(&cfg.Password, prof.Password)
+	default:
+		setIfEmpty(&cfg.ServiceAccount, prof.ServiceAccount)
+		setIfEmpty(&cfg.Password, prof.Password)
+		setIfEmpty(&cfg.Token, prof.Token)
+		setIfEmpty(&cfg.TokenFile, prof.TokenFile)
+	}
+	return nil
+}
+
+func setIfEmpty(v *types.String, val string) {
+	if v.ValueString() == "" && val != "" {
+		*v = types.StringValue(val)
+	}
 }
 
 func validate(cfg *providerModel) []diag.Diagnostic {
@@ -275,18 +806,55 @@ func validate(cfg *providerModel) []diag.Diagnostic {
 				"If you have a customer ID, the host will be derived from it.",
 		))
 	}
-	if cfg.CustomerID.ValueString() != "" && cfg.Host.ValueString() != cfg.CustomerID.ValueString()+".gamefabric.dev" {
+	if cfg.CustomerID.ValueString() != "" && hostName(cfg.Host.ValueString()) != cfg.CustomerID.ValueString()+".gamefabric.dev" {
 		diags.Append(diag.NewErrorDiagnostic(
 			"Conflicting Host and Customer ID",
 			"The provider cannot create the GameFabric client as both the host and customer ID are configured, "+
 				"but the host is not derived from the customer ID. ",
 		))
 	}
+	diags.Append(validateCredentials(cfg)...)
+	return diags
+}
+
+// hostName returns the host name of a host that may be given as a full URL.
+func hostName(host string) string {
+	if u, err := newAPIURL(host); err == nil {
+		return u.Host
+	}
+	return host
+}
+
+func validateCredentials(cfg *providerModel) []diag.Diagnostic {
+	hasPassword := cfg.ServiceAccount.ValueString() != "" || cfg.Password.ValueString() != ""
+	hasToken := cfg.Token.ValueString() != ""
+	hasTokenFile := cfg.TokenFile.ValueString() != ""
+
+	var modes int
+	for _, ok := range []bool{hasPassword, hasToken, hasTokenFile} {
+		if ok {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return []diag.Diagnostic{diag.NewErrorDiagnostic(
+			"Conflicting Credentials",
+			"The provider cannot create the GameFabric client as more than one set of credentials is configured. "+
+				"Please configure only one of service_account and password, token or token_file, "+
+				"either in the provider configuration or using the corresponding environment variables.",
+		)}
+	}
+	if hasToken || hasTokenFile {
+		return nil
+	}
+
+	diags := make(diag.Diagnostics, 0, 2)
 	if cfg.ServiceAccount.ValueString() == "" {
 		diags.Append(diag.NewErrorDiagnostic(
 			"Missing Service Account",
 			"The provider cannot create the GameFabric client as there is no service account configured. "+
-				"Please set the service_account value in the provider configuration or use the "+envServiceAccount+" environment variable.",
+				"Please set the service_account value in the provider configuration or use the "+envServiceAccount+" environment variable. "+
+				"Alternatively, authenticate using token or token_file.",
 		))
 	}
 	if cfg.Password.ValueString() == "" {
@@ -1,11 +1,12 @@
 package provider_test
 
 import (
-	"context"
+	"encoding/pem"
 	"maps"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync/atomic"
@@ -14,12 +15,14 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/fake"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"golang.org/x/oauth2"
 )
 
 func TestProvider_Meta(t *testing.T) {
@@ -39,12 +42,31 @@ func TestProvider_Schema(t *testing.T) {
 	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, resp)
 
 	require.Len(t, resp.Diagnostics, 0)
-	require.Len(t, resp.Schema.Attributes, 4)
+	require.Len(t, resp.Schema.Attributes, 20)
 
 	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "host")
 	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "customer_id")
 	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "service_account")
 	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "password")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "token")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "token_file")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "config_path")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "profile")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "ca_cert_pem")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "ca_cert_file")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "proxy_url")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "insecure_skip_tls_verify")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "max_concurrent_requests")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "requests_per_second")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "disable_list_cache")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "disable_dry_run")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "validate_references")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "default_environment")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "default_labels")
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Attributes)), "default_annotations")
+
+	require.Len(t, resp.Schema.Blocks, 1)
+	assert.Contains(t, slices.Collect(maps.Keys(resp.Schema.Blocks)), "http")
 }
 
 func TestProvider_ConfigureWhenClientSetIsSet(t *testing.T) {
@@ -60,6 +82,7 @@ func TestProvider_ConfigureWhenClientSetIsSet(t *testing.T) {
 
 		assert.NotNil(t, resp.DataSourceData.(*provcontext.Context).ClientSet)
 		assert.NotNil(t, resp.ResourceData.(*provcontext.Context).ClientSet)
+		assert.True(t, resp.ResourceData.(*provcontext.Context).DryRun)
 	})
 }
 
@@ -68,7 +91,7 @@ func TestProvider_ConfigureValidates(t *testing.T) {
 	resp := &tfprovider.ConfigureResponse{}
 
 	// Avoid interference from environment variables.
-	for _, env := range []string{"GAMEFABRIC_HOST", "GAMEFABRIC_CUSTOMER_ID", "GAMEFABRIC_SERVICE_ACCOUNT", "GAMEFABRIC_PASSWORD"} {
+	for _, env := range []string{"GAMEFABRIC_HOST", "GAMEFABRIC_CUSTOMER_ID", "GAMEFABRIC_SERVICE_ACCOUNT", "GAMEFABRIC_PASSWORD", "GAMEFABRIC_TOKEN", "GAMEFABRIC_TOKEN_FILE", "GAMEFABRIC_CONFIG_PATH", "GAMEFABRIC_PROFILE"} {
 		err := os.Unsetenv(env)
 		require.NoError(t, err)
 	}
@@ -76,15 +99,12 @@ func TestProvider_ConfigureValidates(t *testing.T) {
 	prov := provider.New("1.0.0")()
 	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
 	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
-		Config: tfsdk.Config{
-			Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{
-				"host":            tftypes.NewValue(tftypes.String, ""),
-				"customer_id":     tftypes.NewValue(tftypes.String, ""),
-				"service_account": tftypes.NewValue(tftypes.String, ""),
-				"password":        tftypes.NewValue(tftypes.String, ""),
-			}),
-			Schema: schemaResp.Schema,
-		},
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, ""),
+			"customer_id":     tftypes.NewValue(tftypes.String, ""),
+			"service_account": tftypes.NewValue(tftypes.String, ""),
+			"password":        tftypes.NewValue(tftypes.String, ""),
+		}),
 		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
 	}, resp)
 
@@ -98,15 +118,12 @@ func TestProvider_ConfigureValidatesHostAndCustomerID(t *testing.T) {
 	prov := provider.New("1.0.0")()
 	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
 	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
-		Config: tfsdk.Config{
-			Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{
-				"host":            tftypes.NewValue(tftypes.String, "other"),
-				"customer_id":     tftypes.NewValue(tftypes.String, "something"),
-				"service_account": tftypes.NewValue(tftypes.String, "test"),
-				"password":        tftypes.NewValue(tftypes.String, "test"),
-			}),
-			Schema: schemaResp.Schema,
-		},
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, "other"),
+			"customer_id":     tftypes.NewValue(tftypes.String, "something"),
+			"service_account": tftypes.NewValue(tftypes.String, "test"),
+			"password":        tftypes.NewValue(tftypes.String, "test"),
+		}),
 		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
 	}, resp)
 
@@ -117,28 +134,27 @@ func TestProvider_Configure(t *testing.T) {
 	var called atomic.Int64
 	srv := testOAuthServer(t, &called, "service_account", "secr3t")
 
-	// Inject custom HTTP client for the oauth2 call.
-	ctx := context.WithValue(t.Context(), oauth2.HTTPClient, srv.Client())
-
 	schemaResp := &tfprovider.SchemaResponse{}
 	resp := &tfprovider.ConfigureResponse{}
 
 	prov := provider.New("1.0.0")()
 	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
-	prov.Configure(ctx, tfprovider.ConfigureRequest{
-		Config: tfsdk.Config{
-			Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{
-				"host":            tftypes.NewValue(tftypes.String, strings.TrimPrefix(srv.URL, "https://")),
-				"customer_id":     tftypes.NewValue(tftypes.String, nil),
-				"service_account": tftypes.NewValue(tftypes.String, "service_account"),
-				"password":        tftypes.NewValue(tftypes.String, "secr3t"),
-			}),
-			Schema: schemaResp.Schema,
-		},
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"ca_cert_pem":     tftypes.NewValue(tftypes.String, testCACert(srv)),
+			"host":            tftypes.NewValue(tftypes.String, strings.TrimPrefix(srv.URL, "https://")),
+			"customer_id":     tftypes.NewValue(tftypes.String, nil),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, "secr3t"),
+		}),
 		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
 	}, resp)
 
 	require.Len(t, resp.Diagnostics, 0)
+	assert.Zero(t, called.Load())
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
 	assert.True(t, called.Load() > 0)
 
 	assert.NotPanics(t, func() {
@@ -151,28 +167,27 @@ func TestProvider_ConfigureUsesCustomerID(t *testing.T) {
 	var called atomic.Int64
 	srv := testOAuthServer(t, &called, "service_account", "secr3t")
 
-	// Inject custom HTTP client for the oauth2 call.
-	ctx := context.WithValue(t.Context(), oauth2.HTTPClient, srv.Client())
-
 	schemaResp := &tfprovider.SchemaResponse{}
 	resp := &tfprovider.ConfigureResponse{}
 
 	prov := provider.New("1.0.0")()
 	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
-	prov.Configure(ctx, tfprovider.ConfigureRequest{
-		Config: tfsdk.Config{
-			Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{
-				"host":            tftypes.NewValue(tftypes.String, strings.TrimPrefix(srv.URL, "https://")),
-				"customer_id":     tftypes.NewValue(tftypes.String, nil),
-				"service_account": tftypes.NewValue(tftypes.String, "service_account"),
-				"password":        tftypes.NewValue(tftypes.String, "secr3t"),
-			}),
-			Schema: schemaResp.Schema,
-		},
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"ca_cert_pem":     tftypes.NewValue(tftypes.String, testCACert(srv)),
+			"host":            tftypes.NewValue(tftypes.String, strings.TrimPrefix(srv.URL, "https://")),
+			"customer_id":     tftypes.NewValue(tftypes.String, nil),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, "secr3t"),
+		}),
 		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
 	}, resp)
 
 	require.Len(t, resp.Diagnostics, 0)
+	assert.Zero(t, called.Load())
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
 	assert.True(t, called.Load() > 0)
 
 	assert.NotPanics(t, func() {
@@ -185,9 +200,6 @@ func TestProvider_ConfigureUsesEnvs(t *testing.T) {
 	var called atomic.Int64
 	srv := testOAuthServer(t, &called, "service_account", "secr3t")
 
-	// Inject custom HTTP client for the oauth2 call.
-	ctx := context.WithValue(t.Context(), oauth2.HTTPClient, srv.Client())
-
 	schemaResp := &tfprovider.SchemaResponse{}
 	resp := &tfprovider.ConfigureResponse{}
 
@@ -198,21 +210,449 @@ func TestProvider_ConfigureUsesEnvs(t *testing.T) {
 
 	prov := provider.New("1.0.0")()
 	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
-	prov.Configure(ctx, tfprovider.ConfigureRequest{
-		Config: tfsdk.Config{
-			Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{
-				"host":            tftypes.NewValue(tftypes.String, ""),
-				"customer_id":     tftypes.NewValue(tftypes.String, ""),
-				"service_account": tftypes.NewValue(tftypes.String, ""),
-				"password":        tftypes.NewValue(tftypes.String, ""),
-			}),
-			Schema: schemaResp.Schema,
-		},
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"ca_cert_pem":     tftypes.NewValue(tftypes.String, testCACert(srv)),
+			"host":            tftypes.NewValue(tftypes.String, ""),
+			"customer_id":     tftypes.NewValue(tftypes.String, ""),
+			"service_account": tftypes.NewValue(tftypes.String, ""),
+			"password":        tftypes.NewValue(tftypes.String, ""),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+	assert.Zero(t, called.Load())
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
+	assert.True(t, called.Load() > 0)
+
+	assert.NotPanics(t, func() {
+		assert.NotNil(t, resp.DataSourceData.(*provcontext.Context).ClientSet)
+		assert.NotNil(t, resp.ResourceData.(*provcontext.Context).ClientSet)
+	})
+}
+
+func TestProvider_ConfigureValidatesConflictingCredentials(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, "secr3t"),
+			"token":           tftypes.NewValue(tftypes.String, "my-token"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Conflicting Credentials", resp.Diagnostics[0].Summary())
+}
+
+func TestProvider_ConfigurePrefersConfigCredentialsOverEnvs(t *testing.T) {
+	var called atomic.Int64
+	srv := testOAuthServer(t, &called, "service_account", "secr3t")
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	testEnv(t, "GAMEFABRIC_TOKEN", "env-token")
+	testEnv(t, "GAMEFABRIC_TOKEN_FILE", "/env/token")
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"ca_cert_pem":     tftypes.NewValue(tftypes.String, testCACert(srv)),
+			"host":            tftypes.NewValue(tftypes.String, strings.TrimPrefix(srv.URL, "https://")),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, "secr3t"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
+	assert.True(t, called.Load() > 0)
+}
+
+func TestProvider_ConfigureValidatesConflictingEnvCredentials(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	testEnv(t, "GAMEFABRIC_SERVICE_ACCOUNT", "service_account")
+	testEnv(t, "GAMEFABRIC_PASSWORD", "secr3t")
+	testEnv(t, "GAMEFABRIC_TOKEN", "env-token")
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Conflicting Credentials", resp.Diagnostics[0].Summary())
+}
+
+func TestProvider_ConfigureWithToken(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":  tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+			"token": tftypes.NewValue(tftypes.String, "my-token"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+
+	assert.NotPanics(t, func() {
+		assert.NotNil(t, resp.DataSourceData.(*provcontext.Context).ClientSet)
+		assert.NotNil(t, resp.ResourceData.(*provcontext.Context).ClientSet)
+	})
+}
+
+func TestProvider_ConfigureWithTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(path, []byte("my-token\n"), 0o600)
+	require.NoError(t, err)
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	testEnv(t, "GAMEFABRIC_TOKEN_FILE", path)
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+
+	assert.NotPanics(t, func() {
+		assert.NotNil(t, resp.DataSourceData.(*provcontext.Context).ClientSet)
+		assert.NotNil(t, resp.ResourceData.(*provcontext.Context).ClientSet)
+	})
+}
+
+func TestProvider_ConfigureWithMissingTokenFile(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":       tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+			"token_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing")),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.Error(t, err)
+}
+
+func TestProvider_ConfigureWithProfile(t *testing.T) {
+	var called atomic.Int64
+	srv := testOAuthServer(t, &called, "service_account", "secr3t")
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	cfgPath := testProfileConfig(t, `
+profiles:
+  prod:
+    customer_id: prod
+    token: prod-token
+  staging:
+    host: `+strings.TrimPrefix(srv.URL, "https://")+`
+    service_account: service_account
+    password: secr3t
+`)
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"ca_cert_pem": tftypes.NewValue(tftypes.String, testCACert(srv)),
+			"config_path": tftypes.NewValue(tftypes.String, cfgPath),
+			"profile":     tftypes.NewValue(tftypes.String, "staging"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+	assert.Zero(t, called.Load())
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
+	assert.True(t, called.Load() > 0)
+}
+
+func TestProvider_ConfigurePrefersConfigOverProfile(t *testing.T) {
+	var called atomic.Int64
+	srv := testOAuthServer(t, &called, "service_account", "secr3t")
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	testEnv(t, "GAMEFABRIC_PASSWORD", "secr3t")
+	testEnv(t, "GAMEFABRIC_PROFILE", "default")
+	cfgPath := testProfileConfig(t, `
+profiles:
+  default:
+    customer_id: other
+    service_account: other
+    password: other
+`)
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"ca_cert_pem":     tftypes.NewValue(tftypes.String, testCACert(srv)),
+			"host":            tftypes.NewValue(tftypes.String, strings.TrimPrefix(srv.URL, "https://")),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"config_path":     tftypes.NewValue(tftypes.String, cfgPath),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+	assert.Zero(t, called.Load())
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
+	assert.True(t, called.Load() > 0)
+}
+
+func TestProvider_ConfigureWithMissingProfile(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	cfgPath := testProfileConfig(t, `
+profiles:
+  prod:
+    customer_id: prod
+    token: prod-token
+`)
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"config_path": tftypes.NewValue(tftypes.String, cfgPath),
+			"profile":     tftypes.NewValue(tftypes.String, "staging"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Invalid Profile", resp.Diagnostics[0].Summary())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `profile "staging" not found in config file "`+cfgPath+`"`)
+	require.Implements(t, (*diag.DiagnosticWithPath)(nil), resp.Diagnostics[0])
+	assert.Equal(t, path.Root("profile"), resp.Diagnostics[0].(diag.DiagnosticWithPath).Path())
+}
+
+func TestProvider_ConfigureWithMissingConfigFile(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	cfgPath := filepath.Join(t.TempDir(), "missing.yaml")
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"config_path": tftypes.NewValue(tftypes.String, cfgPath),
+			"profile":     tftypes.NewValue(tftypes.String, "staging"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Invalid Profile", resp.Diagnostics[0].Summary())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), `could not read config file "`+cfgPath+`"`)
+	require.Implements(t, (*diag.DiagnosticWithPath)(nil), resp.Diagnostics[0])
+	assert.Equal(t, path.Root("config_path"), resp.Diagnostics[0].(diag.DiagnosticWithPath).Path())
+}
+
+func TestProvider_ConfigureWithURLHost(t *testing.T) {
+	var called atomic.Int64
+	srv := httptest.NewServer(testOAuthHandler(t, &called, "service_account", "secr3t"))
+	t.Cleanup(srv.Close)
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, srv.URL),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, "secr3t"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+	assert.Zero(t, called.Load())
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
+	assert.True(t, called.Load() > 0)
+}
+
+func TestProvider_ConfigureWithCACertFile(t *testing.T) {
+	var called atomic.Int64
+	srv := testOAuthServer(t, &called, "service_account", "secr3t")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte(testCACert(srv)), 0o600))
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, srv.URL),
+			"ca_cert_file":    tftypes.NewValue(tftypes.String, caFile),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, "secr3t"),
+		}),
 		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
 	}, resp)
 
 	require.Len(t, resp.Diagnostics, 0)
+	assert.Zero(t, called.Load())
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.NoError(t, err)
 	assert.True(t, called.Load() > 0)
+}
+
+func TestProvider_ConfigureRejectsUnknownCA(t *testing.T) {
+	var called atomic.Int64
+	srv := testOAuthServer(t, &called, "service_account", "secr3t")
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, srv.URL),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, "secr3t"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+
+	_, err := resp.ResourceData.(*provcontext.Context).TokenSource.Token()
+	require.Error(t, err)
+	assert.Zero(t, called.Load())
+}
+
+func TestProvider_ConfigureWithUnknownConfig(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+			"service_account": tftypes.NewValue(tftypes.String, "service_account"),
+			"password":        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+	assert.Nil(t, resp.Deferred)
+
+	provCtx := resp.ResourceData.(*provcontext.Context)
+	assert.True(t, provCtx.ConfigUnknown)
+	assert.NotNil(t, provCtx.ClientSet)
+	_, err := provCtx.TokenSource.Token()
+	require.Error(t, err)
+}
+
+func TestProvider_ConfigureDefersUnknownConfig(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"token": tftypes.NewValue(tftypes.String, "my-token"),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+	require.NotNil(t, resp.Deferred)
+	assert.Equal(t, tfprovider.DeferredReasonProviderConfigUnknown, resp.Deferred.Reason)
+	assert.Nil(t, resp.ResourceData)
+}
+
+func TestProvider_ConfigureWithHTTP(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	httpType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"timeout":           tftypes.String,
+		"max_retries":       tftypes.Number,
+		"retry_min_backoff": tftypes.String,
+		"retry_max_backoff": tftypes.String,
+	}}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":  tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+			"token": tftypes.NewValue(tftypes.String, "my-token"),
+			"http": tftypes.NewValue(httpType, map[string]tftypes.Value{
+				"timeout":           tftypes.NewValue(tftypes.String, "30s"),
+				"max_retries":       tftypes.NewValue(tftypes.Number, 5),
+				"retry_min_backoff": tftypes.NewValue(tftypes.String, "1s"),
+				"retry_max_backoff": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
 
 	assert.NotPanics(t, func() {
 		assert.NotNil(t, resp.DataSourceData.(*provcontext.Context).ClientSet)
@@ -220,8 +660,74 @@ func TestProvider_ConfigureUsesEnvs(t *testing.T) {
 	})
 }
 
+func TestProvider_ConfigureValidatesRetryBackoff(t *testing.T) {
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	httpType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"timeout":           tftypes.String,
+		"max_retries":       tftypes.Number,
+		"retry_min_backoff": tftypes.String,
+		"retry_max_backoff": tftypes.String,
+	}}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"host":  tftypes.NewValue(tftypes.String, "example.gamefabric.dev"),
+			"token": tftypes.NewValue(tftypes.String, "my-token"),
+			"http": tftypes.NewValue(httpType, map[string]tftypes.Value{
+				"timeout":           tftypes.NewValue(tftypes.String, nil),
+				"max_retries":       tftypes.NewValue(tftypes.Number, nil),
+				"retry_min_backoff": tftypes.NewValue(tftypes.String, "1m"),
+				"retry_max_backoff": tftypes.NewValue(tftypes.String, "10s"),
+			}),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Unable to create GameFabric client", resp.Diagnostics[0].Summary())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "http.retry_min_backoff 1m0s must not be greater than http.retry_max_backoff 10s")
+}
+
+func TestProvider_ConfigureWithLimits(t *testing.T) {
+	var called atomic.Int64
+	srv := testOAuthServer(t, &called, "service_account", "secr3t")
+
+	schemaResp := &tfprovider.SchemaResponse{}
+	resp := &tfprovider.ConfigureResponse{}
+
+	prov := provider.New("1.0.0")()
+	prov.Schema(t.Context(), tfprovider.SchemaRequest{}, schemaResp)
+	prov.Configure(t.Context(), tfprovider.ConfigureRequest{
+		Config: testConfig(t, schemaResp.Schema, map[string]tftypes.Value{
+			"ca_cert_pem":             tftypes.NewValue(tftypes.String, testCACert(srv)),
+			"host":                    tftypes.NewValue(tftypes.String, srv.URL),
+			"service_account":         tftypes.NewValue(tftypes.String, "service_account"),
+			"password":                tftypes.NewValue(tftypes.String, "secr3t"),
+			"max_concurrent_requests": tftypes.NewValue(tftypes.Number, 4),
+			"requests_per_second":     tftypes.NewValue(tftypes.Number, 10.5),
+		}),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{},
+	}, resp)
+
+	require.Len(t, resp.Diagnostics, 0)
+
+	provCtx := resp.ResourceData.(*provcontext.Context)
+	assert.NotNil(t, provCtx.Limiter)
+	_, err := provCtx.TokenSource.Token()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), called.Load())
+}
+
 func testOAuthServer(t *testing.T, called *atomic.Int64, user, pass string) *httptest.Server {
-	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return httptest.NewTLSServer(testOAuthHandler(t, called, user, pass))
+}
+
+func testOAuthHandler(t *testing.T, called *atomic.Int64, user, pass string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/auth/token", r.URL.Path)
 
 		err := r.ParseForm()
@@ -235,7 +741,19 @@ func testOAuthServer(t *testing.T, called *atomic.Int64, user, pass string) *htt
 		_, _ = w.Write([]byte(`{"access_token":"token"}`))
 
 		called.Add(1)
-	}))
+	})
+}
+
+func testCACert(srv *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+}
+
+func testProfileConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	p := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
 }
 
 func testEnv(t *testing.T, key, value string) {
@@ -249,3 +767,20 @@ func testEnv(t *testing.T, key, value string) {
 		require.NoError(t, err)
 	})
 }
+
+func testConfig(t *testing.T, s schema.Schema, vals map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	// Attributes not set by the test are null.
+	typ := s.Type().TerraformType(t.Context()).(tftypes.Object)
+	for name, attrTyp := range typ.AttributeTypes {
+		if _, ok := vals[name]; !ok {
+			vals[name] = tftypes.NewValue(attrTyp, nil)
+		}
+	}
+
+	return tfsdk.Config{
+		Raw:    tftypes.NewValue(typ, vals),
+		Schema: s,
+	}
+}
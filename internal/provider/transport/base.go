@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// BaseOptions configures the base HTTP transport.
+type BaseOptions struct {
+	// CACertPEM holds additional PEM encoded CA certificates to trust
+	// on top of the system certificate pool.
+	CACertPEM []byte
+	// ProxyURL is the proxy to send requests through.
+	// If nil, the proxy is taken from the environment.
+	ProxyURL *url.URL
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// NewBase returns a copy of the default HTTP transport configured with opts.
+func NewBase(opts BaseOptions) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.ProxyURL != nil {
+		t.Proxy = http.ProxyURL(opts.ProxyURL)
+	}
+
+	if len(opts.CACertPEM) == 0 && !opts.InsecureSkipVerify {
+		return t, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec // Explicitly requested by the user for local and testing endpoints.
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+	if len(opts.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, errors.New("no valid PEM encoded certificates found in CA bundle")
+		}
+		tlsCfg.RootCAs = pool
+	}
+	t.TLSClientConfig = tlsCfg
+	return t, nil
+}
@@ -0,0 +1,96 @@
+package transport_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBase_TrustsCACert(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	base, err := transport.NewBase(transport.BaseOptions{CACertPEM: caPEM})
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: base}).Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewBase_RejectsUnknownCA(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	base, err := transport.NewBase(transport.BaseOptions{})
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: base}).Get(srv.URL)
+	require.Error(t, err)
+}
+
+func TestNewBase_InsecureSkipVerify(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	base, err := transport.NewBase(transport.BaseOptions{InsecureSkipVerify: true})
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: base}).Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewBase_InvalidCACert(t *testing.T) {
+	t.Parallel()
+
+	_, err := transport.NewBase(transport.BaseOptions{CACertPEM: []byte("not a certificate")})
+
+	require.Error(t, err)
+}
+
+func TestNewBase_UsesProxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.Host == "api.example.com"
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	base, err := transport.NewBase(transport.BaseOptions{ProxyURL: proxyURL})
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: base}).Get("http://api.example.com/v1")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, proxied)
+}
@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// LimitOptions configures the request limiter.
+type LimitOptions struct {
+	// MaxConcurrent is the maximum number of requests in flight.
+	// Zero means no limit.
+	MaxConcurrent int
+	// RequestsPerSecond is the maximum sustained rate of requests.
+	// Zero means no limit.
+	RequestsPerSecond float64
+}
+
+// Limiter bounds the number of concurrent requests and their rate.
+//
+// A single Limiter is shared by all transports of the provider,
+// so every resource and data source draws from the same budget.
+type Limiter struct {
+	sem  chan struct{}
+	rate *rate.Limiter
+}
+
+// NewLimiter returns a limiter configured with opts.
+func NewLimiter(opts LimitOptions) *Limiter {
+	l := &Limiter{}
+	if opts.MaxConcurrent > 0 {
+		l.sem = make(chan struct{}, opts.MaxConcurrent)
+	}
+	if opts.RequestsPerSecond > 0 {
+		burst := max(1, int(math.Ceil(opts.RequestsPerSecond)))
+		l.rate = rate.NewLimiter(rate.Limit(opts.RequestsPerSecond), burst)
+	}
+	return l
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+// The returned function must be called once the request completed.
+func (l *Limiter) Wait(ctx context.Context) (func(), error) {
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// Limit is a http.RoundTripper that waits for a shared Limiter
+// before sending a request.
+type Limit struct {
+	next    http.RoundTripper
+	limiter *Limiter
+}
+
+// NewLimit returns a limiting transport wrapping next.
+func NewLimit(next http.RoundTripper, limiter *Limiter) *Limit {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Limit{
+		next:    next,
+		limiter: limiter,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction once the limiter allows it.
+func (t *Limit) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	start := time.Now()
+	release, err := t.limiter.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if waited := time.Since(start); waited >= time.Millisecond {
+		tflog.Debug(ctx, "Waited for API request limiter", map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"wait_ms": waited.Milliseconds(),
+		})
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,128 @@
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/transport"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimit_BoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		var inFlight, peak atomic.Int64
+		next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Second)
+			inFlight.Add(-1)
+			return testResponse(http.StatusOK, nil), nil
+		})
+
+		limiter := transport.NewLimiter(transport.LimitOptions{MaxConcurrent: 2})
+		// Two transports sharing one limiter share its budget.
+		clients := []*http.Client{
+			{Transport: transport.NewLimit(next, limiter)},
+			{Transport: transport.NewLimit(next, limiter)},
+		}
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for i := range 6 {
+			wg.Go(func() {
+				resp, err := clients[i%2].Get("http://example.com")
+				if assert.NoError(t, err) {
+					_ = resp.Body.Close()
+				}
+			})
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(2), peak.Load())
+		assert.Equal(t, 3*time.Second, time.Since(start))
+	})
+}
+
+func TestLimit_BoundsRate(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		var times []time.Time
+		next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			times = append(times, time.Now())
+			return testResponse(http.StatusOK, nil), nil
+		})
+
+		var buf bytes.Buffer
+		ctx := tflogtest.RootLogger(t.Context(), &buf)
+		client := &http.Client{Transport: transport.NewLimit(next, transport.NewLimiter(transport.LimitOptions{RequestsPerSecond: 2}))}
+
+		for range 4 {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/v1/test", nil)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+		}
+
+		require.Len(t, times, 4)
+		assert.Equal(t, time.Duration(0), times[1].Sub(times[0]))
+		assert.Equal(t, 500*time.Millisecond, times[2].Sub(times[1]))
+		assert.Equal(t, 500*time.Millisecond, times[3].Sub(times[2]))
+
+		entries, err := tflogtest.MultilineJSONDecode(&buf)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "Waited for API request limiter", entries[0]["@message"])
+		assert.Equal(t, "/v1/test", entries[0]["path"])
+		assert.InDelta(t, float64(500), entries[0]["wait_ms"], 0)
+	})
+}
+
+func TestLimit_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		block := make(chan struct{})
+		next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			<-block
+			return testResponse(http.StatusOK, nil), nil
+		})
+		client := &http.Client{Transport: transport.NewLimit(next, transport.NewLimiter(transport.LimitOptions{MaxConcurrent: 1}))}
+
+		go func() {
+			resp, err := client.Get("http://example.com")
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		synctest.Wait()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		t.Cleanup(cancel)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		close(block)
+	})
+}
@@ -0,0 +1,66 @@
+package transport
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// requestIDHeader is the header carrying the ID the API assigned to a request.
+const requestIDHeader = "X-Request-Id"
+
+// Logging is a http.RoundTripper that logs every request with tflog.
+//
+// The request is logged at TRACE before it is sent, and its method, path,
+// status, latency and request ID are logged at DEBUG once it completes.
+// Headers and bodies are never logged, as they may contain credentials.
+type Logging struct {
+	next http.RoundTripper
+}
+
+// NewLogging returns a logging transport wrapping next.
+func NewLogging(next http.RoundTripper) *Logging {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Logging{next: next}
+}
+
+// RoundTrip executes a single HTTP transaction, logging it.
+func (t *Logging) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	tflog.Trace(ctx, "Sending API request", map[string]any{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	fields := map[string]any{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "API request failed", fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if id := requestID(req, resp); id != "" {
+		fields["request_id"] = id
+	}
+	tflog.Debug(ctx, "API request completed", fields)
+	return resp, nil
+}
+
+func requestID(req *http.Request, resp *http.Response) string {
+	if id := resp.Header.Get(requestIDHeader); id != "" {
+		return id
+	}
+	return req.Header.Get(requestIDHeader)
+}
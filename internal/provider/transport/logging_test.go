@@ -0,0 +1,72 @@
+package transport_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/transport"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogging_LogsRequest(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &buf)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/core/v1/environments", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer my-token")
+
+	resp, err := (&http.Client{Transport: transport.NewLogging(srv.Client().Transport)}).Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "trace", entries[0]["@level"])
+	assert.Equal(t, "Sending API request", entries[0]["@message"])
+	assert.Equal(t, "debug", entries[1]["@level"])
+	assert.Equal(t, "API request completed", entries[1]["@message"])
+	assert.Equal(t, "GET", entries[1]["method"])
+	assert.Equal(t, "/api/core/v1/environments", entries[1]["path"])
+	assert.InDelta(t, float64(http.StatusNotFound), entries[1]["status"], 0)
+	assert.Equal(t, "req-123", entries[1]["request_id"])
+	assert.Contains(t, entries[1], "duration_ms")
+	assert.NotContains(t, buf.String(), "my-token")
+}
+
+func TestLogging_LogsError(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &buf)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, "http://api.example.com/v1/test", nil)
+	require.NoError(t, err)
+
+	next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	_, err = transport.NewLogging(next).RoundTrip(req)
+	require.Error(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "API request failed", entries[1]["@message"])
+	assert.Equal(t, "DELETE", entries[1]["method"])
+	assert.Equal(t, "connection refused", entries[1]["error"])
+}
@@ -0,0 +1,185 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+)
+
+// RetryOptions configures the retrying transport.
+type RetryOptions struct {
+	// Timeout is the timeout of a single request attempt.
+	// Zero means no timeout.
+	Timeout time.Duration
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// MinBackoff is the backoff before the first retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the upper bound of the backoff between retries,
+	// including a delay requested by a Retry-After header.
+	MaxBackoff time.Duration
+}
+
+// Retry is a http.RoundTripper that retries idempotent requests
+// on rate limiting, server errors and connection resets.
+type Retry struct {
+	next http.RoundTripper
+	opts RetryOptions
+}
+
+// NewRetry returns a retrying transport wrapping next.
+func NewRetry(next http.RoundTripper, opts RetryOptions) *Retry {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Retry{
+		next: next,
+		opts: opts,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, retrying it when appropriate.
+func (t *Retry) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req.Method) || !canReplayBody(req) {
+		return t.roundTrip(req)
+	}
+
+	bo := &backoff.ExponentialBackOff{
+		InitialInterval:     t.opts.MinBackoff,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          2,
+		MaxInterval:         t.opts.MaxBackoff,
+	}
+	bo.Reset()
+
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.roundTrip(attemptReq)
+		if attempt >= t.opts.MaxRetries || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := bo.NextBackOff()
+		if d, ok := retryAfter(resp); ok {
+			wait = min(d, t.opts.MaxBackoff)
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *Retry) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.opts.Timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.opts.Timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The timeout also covers reading the body, so only cancel once it is closed.
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func canReplayBody(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isConnectionReset(err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusNotImplemented:
+		return false
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return true
+	default:
+		return false
+	}
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryAfter returns the delay requested by the Retry-After header,
+// which is either a number of seconds or a HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+type cancelBody struct {
+	io.ReadCloser
+
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
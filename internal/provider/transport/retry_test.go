@@ -0,0 +1,301 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry_RetriesServerErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: transport.NewRetry(srv.Client().Transport, testRetryOptions(3))}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestRetry_RetriesTooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: transport.NewRetry(srv.Client().Transport, testRetryOptions(3))}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestRetry_RetriesConnectionReset(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: transport.NewRetry(srv.Client().Transport, testRetryOptions(3))}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodDelete, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestRetry_ReplaysBody(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"foo":"bar"}`, string(body))
+
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: transport.NewRetry(srv.Client().Transport, testRetryOptions(3))}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPut, srv.URL, strings.NewReader(`{"foo":"bar"}`))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestRetry_DoesNotRetryNonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int64
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			t.Cleanup(srv.Close)
+
+			client := &http.Client{Transport: transport.NewRetry(srv.Client().Transport, testRetryOptions(3))}
+
+			req, err := http.NewRequestWithContext(t.Context(), method, srv.URL, strings.NewReader("{}"))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = resp.Body.Close() })
+
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.Equal(t, int64(1), calls.Load())
+		})
+	}
+}
+
+func TestRetry_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: transport.NewRetry(srv.Client().Transport, testRetryOptions(3))}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestRetry_ReturnsLastResponseWhenRetriesExhausted(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: transport.NewRetry(srv.Client().Transport, testRetryOptions(2))}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "boom", string(body))
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		var times []time.Time
+		next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			times = append(times, time.Now())
+			if len(times) == 1 {
+				return testResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"7"}}), nil
+			}
+			return testResponse(http.StatusOK, nil), nil
+		})
+
+		opts := testRetryOptions(3)
+		opts.MaxBackoff = time.Minute
+		client := &http.Client{Transport: transport.NewRetry(next, opts)}
+
+		resp, err := client.Get("http://example.com")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Len(t, times, 2)
+		assert.Equal(t, 7*time.Second, times[1].Sub(times[0]))
+	})
+}
+
+func TestRetry_ClampsRetryAfterToMaxBackoff(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		var times []time.Time
+		next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			times = append(times, time.Now())
+			if len(times) == 1 {
+				return testResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"3600"}}), nil
+			}
+			return testResponse(http.StatusOK, nil), nil
+		})
+
+		opts := testRetryOptions(3)
+		opts.MaxBackoff = 30 * time.Second
+		client := &http.Client{Transport: transport.NewRetry(next, opts)}
+
+		resp, err := client.Get("http://example.com")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Len(t, times, 2)
+		assert.Equal(t, 30*time.Second, times[1].Sub(times[0]))
+	})
+}
+
+func TestRetry_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, syscall.ECONNRESET
+		})
+
+		client := &http.Client{Transport: transport.NewRetry(next, transport.RetryOptions{
+			MaxRetries: 10,
+			MinBackoff: time.Minute,
+			MaxBackoff: time.Hour,
+		})}
+
+		ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+		t.Cleanup(cancel)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func testRetryOptions(maxRetries int) transport.RetryOptions {
+	return transport.RetryOptions{
+		Timeout:    5 * time.Second,
+		MaxRetries: maxRetries,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testResponse(code int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
-	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,16 +12,25 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	armadareg "github.com/gamefabric/gf-core/pkg/apiserver/registry/armada/armada"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/deletionprotection"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dryrun"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
-	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/tfutils"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -38,6 +45,8 @@ var (
 	_ resource.Resource                = &armada{}
 	_ resource.ResourceWithConfigure   = &armada{}
 	_ resource.ResourceWithImportState = &armada{}
+	_ resource.ResourceWithModifyPlan  = &armada{}
+	_ resource.ResourceWithIdentity    = &armada{}
 )
 
 var armadaValidator = validators.NewGameFabricValidator[*armadav1.Armada, armadaModel](func() validators.StoreValidator {
@@ -48,7 +57,14 @@ var armadaValidator = validators.NewGameFabricValidator[*armadav1.Armada, armada
 })
 
 type armada struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	lists              *listcache.Cache
+	configUnknown      bool
+	dryRun             bool
+	validateReferences bool
+	defaultEnvironment types.String
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewArmada returns a new instance of the Armada resource.
@@ -62,7 +78,7 @@ func (r *armada) Metadata(_ context.Context, req resource.MetadataRequest, resp
 }
 
 // Schema defines the schema for this data source.
-func (r *armada) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) { //nolint:maintidx // Keep schema in one place.
+func (r *armada) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) { //nolint:maintidx // Keep schema in one place.
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -86,14 +102,15 @@ func (r *armada) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				},
 			},
 			"environment": schema.StringAttribute{
-				Description:         "The name of the environment the resource belongs to.",
-				MarkdownDescription: "The name of the environment the resource belongs to.",
-				Required:            true,
+				Description:         "The name of the environment the resource belongs to. Defaults to the provider's default_environment.",
+				MarkdownDescription: "The name of the environment the resource belongs to. Defaults to the provider's `default_environment`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					validators.EnvironmentValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"description": schema.StringAttribute{
@@ -119,6 +136,18 @@ func (r *armada) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"autoscaling": schema.SingleNestedAttribute{
 				Description:         "Autoscaling configuration for the game servers.",
 				MarkdownDescription: "Autoscaling configuration for the game servers.",
@@ -182,6 +211,9 @@ func (r *armada) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				Description:         "A replicas specifies the distribution of game servers across the available types of capacity in the selected region type.",
 				MarkdownDescription: "A replicas specifies the distribution of game servers across the available types of capacity in the selected region type.",
 				Optional:            true,
+				Validators: []validator.List{
+					validators.UniqueAttributeValidator{Attribute: "region_type"},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"region_type": schema.StringAttribute{
@@ -410,6 +442,11 @@ func (r *armada) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				MarkdownDescription: "ProfilingEnabled indicates whether profiling is enabled for the Armada.",
 				Optional:            true,
 			},
+			"wait_for_rollout": schema.BoolAttribute{
+				Description:         "Wait on create and update until the Armada is rolled out: its latest change was acted on and each region type has at least 'min_replicas' ready game servers. The wait is bounded by the 'timeouts' block. Defaults to 'false'.",
+				MarkdownDescription: "Wait on create and update until the Armada is rolled out: its latest change was acted on and each region type has at least `min_replicas` ready game servers. The wait is bounded by the `timeouts` block. Defaults to `false`.",
+				Optional:            true,
+			},
 			"image_updater_target": schema.SingleNestedAttribute{
 				Description:         "ImageUpdaterTarget is the reference that an image updater can target to match the Armada.",
 				MarkdownDescription: "ImageUpdaterTarget is the reference that an image updater can target to match the Armada.",
@@ -435,10 +472,19 @@ func (r *armada) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 					},
 				},
 			},
+			"deletion_protection": deletionprotection.Attribute("Armada"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *armada) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *armada) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -455,6 +501,13 @@ func (r *armada) Configure(_ context.Context, req resource.ConfigureRequest, res
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
+	r.configUnknown = procCtx.ConfigUnknown
+	r.dryRun = procCtx.DryRun
+	r.validateReferences = procCtx.ValidateReferences
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *armada) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -464,7 +517,11 @@ func (r *armada) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Create(ctx, plan.Timeouts)
+	defer cancel()
+
 	obj := plan.ToObject()
+	logging.Object(ctx, "Armada", obj)
 	outObj, err := r.clientSet.ArmadaV1().Armadas(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -474,9 +531,22 @@ func (r *armada) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForRollout := plan.WaitForRollout
+	deletionProtection := plan.DeletionProtection
 	plan = newArmadaModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForRollout = waitForRollout
+	plan.Timeouts = cfgTimeouts
+	plan.DeletionProtection = deletionProtection
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForRollout.ValueBool() {
+		resp.Diagnostics.Append(r.waitForRollout(ctx, plan)...)
+	}
 }
 
 func (r *armada) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -500,9 +570,19 @@ func (r *armada) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 		return
 	}
 
+	labels, annotations, cfgTimeouts := state.Labels, state.Annotations, state.Timeouts
+	waitForRollout := state.WaitForRollout
+	deletionProtection := state.DeletionProtection
 	state = newArmadaModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
+	state.WaitForRollout = waitForRollout
+	state.Timeouts = cfgTimeouts
+	state.DeletionProtection = deletionProtection
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.Name})...)
 }
 
 func (r *armada) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -517,6 +597,9 @@ func (r *armada) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Update(ctx, plan.Timeouts)
+	defer cancel()
+
 	oldObj := state.ToObject()
 	newObj := plan.ToObject()
 
@@ -529,26 +612,49 @@ func (r *armada) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
-	if _, err = r.clientSet.ArmadaV1().Armadas(newObj.Environment).Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Armada", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.ArmadaV1().Armadas(newObj.Environment), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Armada", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Armada",
 			fmt.Sprintf("Could not patch Armada: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
-	plan.ImageUpdaterTarget = container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeArmada, oldObj.Name, oldObj.Environment)
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForRollout := plan.WaitForRollout
+	deletionProtection := plan.DeletionProtection
+	plan = newArmadaModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForRollout = waitForRollout
+	plan.Timeouts = cfgTimeouts
+	plan.DeletionProtection = deletionProtection
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForRollout.ValueBool() {
+		resp.Diagnostics.Append(r.waitForRollout(ctx, plan)...)
+	}
 }
 
 func (r *armada) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state armadaModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(deletionprotection.Check("Armada", state.Name.ValueString(), state.DeletionProtection)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := timeouts.Delete(ctx, state.Timeouts)
+	defer cancel()
+
 	err := r.clientSet.ArmadaV1().Armadas(state.Environment.ValueString()).Delete(ctx, state.Name.ValueString(), metav1.DeleteOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -567,12 +673,123 @@ func (r *armada) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 	}
 }
 
+// ModifyPlan fills in the provider's default environment when none is configured
+// and merges the provider's default labels and annotations. It warns about updates
+// that replace or shut down running game servers.
+func (r *armada) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("environment"), r.defaultEnvironment, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+	r.warnDisruption(ctx, req, resp)
+	r.checkReferences(ctx, req, resp)
+	r.checkRegionTypes(ctx, req, resp)
+	r.dryRunPlan(ctx, req, resp)
+}
+
 func (r *armada) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if req.ID == "" {
+	identity.ImportNamespaced(ctx, req, resp, path.Root("name"), r.defaultEnvironment)
+}
+
+// waitForRollout waits until the Armada is rolled out.
+func (r *armada) waitForRollout(ctx context.Context, m armadaModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	cond := wait.RolledOut(armadaRolloutStatus, minReadyReplicas(m.Replicas))
+	if err := wait.PollUntil(ctx, r.clientSet.ArmadaV1().Armadas(m.Environment.ValueString()), m.Name.ValueString(), cond, wait.Awaiting("rollout")); err != nil {
+		diags.AddError(
+			"Error Waiting for Armada Rollout",
+			fmt.Sprintf("Could not wait for rollout of Armada: %v", err),
+		)
+	}
+	return diags
+}
+
+// warnDisruption warns if the planned update replaces or shuts down running game servers.
+func (r *armada) warnDisruption(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		// Nothing is running yet.
+		return
+	}
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
 		return
 	}
 
-	env, name := cache.SplitMetaNamespaceKey(req.ID)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+	var plan armadaModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state armadaModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replace := make([]string, 0, len(resp.RequiresReplace))
+	for _, p := range resp.RequiresReplace {
+		replace = append(replace, p.String())
+	}
+	unknown := tfutils.UnknownAttributes(resp.Plan, disruptiveAttributes...)
+	change := classifyArmadaChange(state.ToObject(), plan.ToObject(), replace, unknown)
+	if change.Disruption == inPlace {
+		return
+	}
+
+	allocated := int32(-1)
+	// clientSet may be nil during unit tests that do not configure the provider,
+	// and the API cannot be reached while the provider configuration is unknown.
+	if r.clientSet != nil && !r.configUnknown {
+		// The status only refines the warning, so it is left out if it cannot be read.
+		obj, err := r.clientSet.ArmadaV1().Armadas(state.Environment.ValueString()).Get(ctx, state.Name.ValueString(), metav1.GetOptions{})
+		if err == nil {
+			allocated = allocatedReplicas(obj, change.RegionTypes)
+		}
+	}
+	resp.Diagnostics.Append(change.diagnostics(allocated)...)
+}
+
+// checkReferences reports references of the planned Armada to config files, secrets
+// and gateway policies that do not exist.
+func (r *armada) checkReferences(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The API cannot be reached while the provider configuration is unknown.
+	if !r.validateReferences || r.configUnknown || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan armadaModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(mps.ValidateReferences(ctx, r.clientSet, plan.Environment, plan.Containers, plan.GatewayPolicies)...)
+}
+
+// checkRegionTypes warns about region types of the planned replicas that their region does not define.
+func (r *armada) checkRegionTypes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// clientSet may be nil during unit tests that do not configure the provider,
+	// and the API cannot be reached while the provider configuration is unknown.
+	if r.clientSet == nil || r.configUnknown || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan armadaModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !conv.IsKnown(plan.Environment) || !conv.IsKnown(plan.Region) {
+		return
+	}
+	resp.Diagnostics.Append(validateRegionTypes(ctx, r.clientSet, r.lists, plan.Environment.ValueString(), plan.Region.ValueString(), plan.Replicas, path.Root("replicas"))...)
+}
+
+// dryRunPlan validates the planned Armada with a server-side dry run.
+func (r *armada) dryRunPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	dryrun.ModifyPlan(ctx, r.dryRun, dryrun.Resource[*armadav1.Armada, armadaModel]{
+		Kind: "Armada",
+		Client: func(plan armadaModel) dryrun.Client[*armadav1.Armada] {
+			return r.clientSet.ArmadaV1().Armadas(plan.Environment.ValueString())
+		},
+		Name:     func(m armadaModel) string { return m.Name.ValueString() },
+		ToObject: armadaModel.ToObject,
+	}, req, resp)
 }
@@ -0,0 +1,39 @@
+package armada
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	armadav1 "github.com/gamefabric/gf-core/pkg/api/armada/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewArmadaList returns a new instance of the Armada list resource.
+func NewArmadaList() list.ListResource {
+	return listresource.NewNamespaced(listresource.Options[armadav1.Armada]{
+		TypeName: "armada",
+		Plural:   "armadas",
+		Kinds:    "Armadas",
+		List: func(ctx context.Context, cs clientset.Interface, env string, opts metav1.ListOptions) ([]armadav1.Armada, error) {
+			objs, err := cs.ArmadaV1().Armadas(env).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *armadav1.Armada, defaults listresource.Defaults) listresource.Result {
+			m := newArmadaModel(obj)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Namespaced{Environment: m.Environment, Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
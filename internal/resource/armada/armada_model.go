@@ -7,6 +7,7 @@ import (
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -21,6 +22,8 @@ type armadaModel struct {
 	Description           types.String                       `tfsdk:"description"`
 	Labels                map[string]types.String            `tfsdk:"labels"`
 	Annotations           map[string]types.String            `tfsdk:"annotations"`
+	LabelsAll             map[string]types.String            `tfsdk:"labels_all"`
+	AnnotationsAll        map[string]types.String            `tfsdk:"annotations_all"`
 	Autoscaling           *armadaAutoscalingModel            `tfsdk:"autoscaling"`
 	Region                types.String                       `tfsdk:"region"`
 	Replicas              []replicaModel                     `tfsdk:"replicas"`
@@ -34,6 +37,9 @@ type armadaModel struct {
 	GatewayPolicies       []types.String                     `tfsdk:"gateway_policies"`
 	ProfilingEnabled      types.Bool                         `tfsdk:"profiling_enabled"`
 	ImageUpdaterTarget    *container.ImageUpdaterTargetModel `tfsdk:"image_updater_target"`
+	WaitForRollout        types.Bool                         `tfsdk:"wait_for_rollout"`
+	DeletionProtection    types.Bool                         `tfsdk:"deletion_protection"`
+	Timeouts              timeouts.Value                     `tfsdk:"timeouts"`
 }
 
 func newArmadaModel(obj *armadav1.Armada) armadaModel {
@@ -44,6 +50,8 @@ func newArmadaModel(obj *armadav1.Armada) armadaModel {
 		Description:           conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
 		Labels:                conv.ForEachMapItem(obj.Labels, types.StringValue),
 		Annotations:           conv.ForEachMapItem(obj.Annotations, types.StringValue),
+		LabelsAll:             conv.ForEachMapItem(obj.Labels, types.StringValue),
+		AnnotationsAll:        conv.ForEachMapItem(obj.Annotations, types.StringValue),
 		Autoscaling:           newArmadaAutoscalingModel(obj.Spec.Autoscaling),
 		Region:                types.StringValue(obj.Spec.Region),
 		Replicas:              conv.ForEachSliceItem(obj.Spec.Distribution, newReplicas),
@@ -57,6 +65,7 @@ func newArmadaModel(obj *armadav1.Armada) armadaModel {
 		GatewayPolicies:       conv.ForEachSliceItem(obj.Spec.Template.Spec.GatewayPolicies, types.StringValue),
 		ProfilingEnabled:      conv.BoolFromMapKey(obj.Spec.Template.Labels, profilingKey, types.BoolValue(false)),
 		ImageUpdaterTarget:    container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeArmada, obj.Name, obj.Environment),
+		Timeouts:              timeouts.Null(),
 	}
 }
 
@@ -65,8 +74,8 @@ func (m armadaModel) ToObject() *armadav1.Armada {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
 			Environment: m.Environment.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(v types.String) string { return v.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(v types.String) string { return v.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(v types.String) string { return v.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(v types.String) string { return v.ValueString() }),
 		},
 		Spec: armadav1.ArmadaSpec{
 			Description: m.Description.ValueString(),
@@ -10,6 +10,7 @@ import (
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/core"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -188,6 +189,12 @@ var (
 		Annotations: map[string]types.String{
 			"annotation-key": types.StringValue("annotation-value"),
 		},
+		LabelsAll: map[string]types.String{
+			"label-key": types.StringValue("label-value"),
+		},
+		AnnotationsAll: map[string]types.String{
+			"annotation-key": types.StringValue("annotation-value"),
+		},
 		Description: types.StringValue("Armada Description"),
 		Region:      types.StringValue("eu"),
 		Replicas: []replicaModel{
@@ -321,5 +328,6 @@ var (
 		},
 		ProfilingEnabled:   types.BoolValue(true),
 		ImageUpdaterTarget: container.NewImageUpdaterTargetModel("armada", "test-armada", "test-environment"),
+		Timeouts:           timeouts.Null(),
 	}
 )
@@ -184,6 +184,44 @@ func TestResourceArmadaConfigBasic(t *testing.T) {
 	})
 }
 
+func TestResourceArmadaDefaultEnvironment(t *testing.T) {
+	t.Parallel()
+
+	pf, cs := providertest.ProtoV6ProviderFactories(t)
+
+	provider := `provider "gamefabric" {
+  default_environment = "dflt"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: pf,
+		CheckDestroy:             testCheckArmadaDestroy(t, cs),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + strings.Replace(testResourceArmadaConfigBasic(), `environment = "test"`, "", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gamefabric_armada.test", "id", "dflt/my-armada"),
+					resource.TestCheckResourceAttr("gamefabric_armada.test", "environment", "dflt"),
+				),
+			},
+			{
+				ResourceName:  "gamefabric_armada.test",
+				ImportState:   true,
+				ImportStateId: "my-armada",
+			},
+			{
+				Config: provider + testResourceArmadaConfigBasic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gamefabric_armada.test", "id", "test/my-armada"),
+					resource.TestCheckResourceAttr("gamefabric_armada.test", "environment", "test"),
+				),
+			},
+		},
+	})
+}
+
 func TestResourceArmadaConfigAutoscaling(t *testing.T) {
 	t.Parallel()
 
@@ -385,9 +423,9 @@ func TestResourceArmadaConfigValidates(t *testing.T) {
 			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "name" is required`)),
 		},
 		{
-			name:        "requires environment",
-			config:      testResourceArmadaConfigEmpty(),
-			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "environment" is required`)),
+			name:        "requires environment without default environment",
+			config:      strings.Replace(testResourceArmadaConfigBasic(), `environment = "test"`, "", 1),
+			expectError: regexp.MustCompile(regexp.QuoteMeta(`Missing Environment`)),
 		},
 		{
 			name:        "requires containers",
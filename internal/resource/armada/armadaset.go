@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
-	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,18 +12,27 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	armadasetreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/armada/armadaset"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dryrun"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/core"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/tfutils"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -40,6 +47,8 @@ var (
 	_ resource.Resource                = &armadaSet{}
 	_ resource.ResourceWithConfigure   = &armadaSet{}
 	_ resource.ResourceWithImportState = &armadaSet{}
+	_ resource.ResourceWithModifyPlan  = &armadaSet{}
+	_ resource.ResourceWithIdentity    = &armadaSet{}
 )
 
 var armadaSetValidator = validators.NewGameFabricValidator[*armadav1.ArmadaSet, armadaSetModel](func() validators.StoreValidator {
@@ -50,7 +59,14 @@ var armadaSetValidator = validators.NewGameFabricValidator[*armadav1.ArmadaSet,
 })
 
 type armadaSet struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	lists              *listcache.Cache
+	configUnknown      bool
+	dryRun             bool
+	validateReferences bool
+	defaultEnvironment types.String
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewArmadaSet returns a new instance of the ArmadaSet resource.
@@ -64,7 +80,7 @@ func (r *armadaSet) Metadata(_ context.Context, req resource.MetadataRequest, re
 }
 
 // Schema defines the schema for the resource.
-func (r *armadaSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) { //nolint:maintidx // Keep schema in one place.
+func (r *armadaSet) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) { //nolint:maintidx // Keep schema in one place.
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -88,14 +104,15 @@ func (r *armadaSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 				},
 			},
 			"environment": schema.StringAttribute{
-				Description:         "The name of the environment the resource belongs to.",
-				MarkdownDescription: "The name of the environment the resource belongs to.",
-				Required:            true,
+				Description:         "The name of the environment the resource belongs to. Defaults to the provider's default_environment.",
+				MarkdownDescription: "The name of the environment the resource belongs to. Defaults to the provider's `default_environment`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					validators.EnvironmentValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"description": schema.StringAttribute{
@@ -121,6 +138,18 @@ func (r *armadaSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"autoscaling": schema.SingleNestedAttribute{
 				Description:         "Autoscaling configuration for the game servers.",
 				MarkdownDescription: "Autoscaling configuration for the game servers.",
@@ -239,6 +268,7 @@ func (r *armadaSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 							Required:            true,
 							Validators: []validator.List{
 								listvalidator.SizeAtLeast(1),
+								validators.UniqueAttributeValidator{Attribute: "region_type"},
 							},
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
@@ -541,6 +571,11 @@ func (r *armadaSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 				MarkdownDescription: "ProfilingEnabled indicates whether profiling is enabled for the Armada.",
 				Optional:            true,
 			},
+			"wait_for_rollout": schema.BoolAttribute{
+				Description:         "Wait on create and update until the ArmadaSet is rolled out: its latest change was acted on and each region type of each region has at least 'min_replicas' ready game servers. The wait is bounded by the 'timeouts' block. Defaults to 'false'.",
+				MarkdownDescription: "Wait on create and update until the ArmadaSet is rolled out: its latest change was acted on and each region type of each region has at least `min_replicas` ready game servers. The wait is bounded by the `timeouts` block. Defaults to `false`.",
+				Optional:            true,
+			},
 			"image_updater_target": schema.SingleNestedAttribute{
 				Description:         "ImageUpdaterTarget is the reference that an image updater can target to match the Armada.",
 				MarkdownDescription: "ImageUpdaterTarget is the reference that an image updater can target to match the Armada.",
@@ -567,9 +602,17 @@ func (r *armadaSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *armadaSet) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *armadaSet) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -586,6 +629,13 @@ func (r *armadaSet) Configure(_ context.Context, req resource.ConfigureRequest,
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
+	r.configUnknown = procCtx.ConfigUnknown
+	r.dryRun = procCtx.DryRun
+	r.validateReferences = procCtx.ValidateReferences
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *armadaSet) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -595,7 +645,11 @@ func (r *armadaSet) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	ctx, cancel := timeouts.Create(ctx, plan.Timeouts)
+	defer cancel()
+
 	obj := plan.ToObject()
+	logging.Object(ctx, "ArmadaSet", obj)
 	outObj, err := r.clientSet.ArmadaV1().ArmadaSets(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -605,10 +659,21 @@ func (r *armadaSet) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForRollout := plan.WaitForRollout
 	plan = newArmadaSetModel(outObj, plan.Autoscaling)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForRollout = waitForRollout
+	plan.Timeouts = cfgTimeouts
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForRollout.ValueBool() {
+		resp.Diagnostics.Append(r.waitForRollout(ctx, plan)...)
+	}
 }
 
 func (r *armadaSet) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -632,10 +697,18 @@ func (r *armadaSet) Read(ctx context.Context, req resource.ReadRequest, resp *re
 		return
 	}
 
+	labels, annotations, cfgTimeouts := state.Labels, state.Annotations, state.Timeouts
+	waitForRollout := state.WaitForRollout
 	state = newArmadaSetModel(outObj, state.Autoscaling)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
+	state.WaitForRollout = waitForRollout
+	state.Timeouts = cfgTimeouts
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.Name})...)
 }
 
 func (r *armadaSet) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -650,6 +723,9 @@ func (r *armadaSet) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	ctx, cancel := timeouts.Update(ctx, plan.Timeouts)
+	defer cancel()
+
 	if state.Autoscaling != nil {
 		// Populating global scale to zero setting here is not fine.
 		// The state already reflects reality: Global setting is set, (but) region setting is not.
@@ -670,17 +746,34 @@ func (r *armadaSet) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	if _, err = r.clientSet.ArmadaV1().ArmadaSets(newObj.Environment).Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "ArmadaSet", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.ArmadaV1().ArmadaSets(newObj.Environment), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("ArmadaSet", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching ArmadaSet",
 			fmt.Sprintf("Could not patch ArmadaSet: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
-	plan.ImageUpdaterTarget = container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeArmadaSet, oldObj.Name, oldObj.Environment)
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForRollout := plan.WaitForRollout
+	plan = newArmadaSetModel(outObj, plan.Autoscaling)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForRollout = waitForRollout
+	plan.Timeouts = cfgTimeouts
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForRollout.ValueBool() {
+		resp.Diagnostics.Append(r.waitForRollout(ctx, plan)...)
+	}
 }
 
 func (r *armadaSet) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -690,6 +783,9 @@ func (r *armadaSet) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	ctx, cancel := timeouts.Delete(ctx, state.Timeouts)
+	defer cancel()
+
 	err := r.clientSet.ArmadaV1().ArmadaSets(state.Environment.ValueString()).Delete(ctx, state.Name.ValueString(), metav1.DeleteOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -708,12 +804,90 @@ func (r *armadaSet) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	}
 }
 
+// ModifyPlan fills in the provider's default environment when none is configured
+// and merges the provider's default labels and annotations.
+func (r *armadaSet) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("environment"), r.defaultEnvironment, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+	r.checkReferences(ctx, req, resp)
+	r.checkRegionTypes(ctx, req, resp)
+	r.dryRunPlan(ctx, req, resp)
+}
+
 func (r *armadaSet) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if req.ID == "" {
+	identity.ImportNamespaced(ctx, req, resp, path.Root("name"), r.defaultEnvironment)
+}
+
+// waitForRollout waits until the ArmadaSet is rolled out.
+func (r *armadaSet) waitForRollout(ctx context.Context, m armadaSetModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	cond := wait.RolledOut(armadaSetRolloutStatus, minReadyReplicasByRegion(m.Regions))
+	if err := wait.PollUntil(ctx, r.clientSet.ArmadaV1().ArmadaSets(m.Environment.ValueString()), m.Name.ValueString(), cond, wait.Awaiting("rollout")); err != nil {
+		diags.AddError(
+			"Error Waiting for ArmadaSet Rollout",
+			fmt.Sprintf("Could not wait for rollout of ArmadaSet: %v", err),
+		)
+	}
+	return diags
+}
+
+// checkReferences reports references of the planned ArmadaSet to config files, secrets
+// and gateway policies that do not exist.
+func (r *armadaSet) checkReferences(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The API cannot be reached while the provider configuration is unknown.
+	if !r.validateReferences || r.configUnknown || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan armadaSetModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(mps.ValidateReferences(ctx, r.clientSet, plan.Environment, plan.Containers, plan.GatewayPolicies)...)
+}
+
+// checkRegionTypes warns about region types of the planned replicas that their region does not define.
+func (r *armadaSet) checkRegionTypes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// clientSet may be nil during unit tests that do not configure the provider,
+	// and the API cannot be reached while the provider configuration is unknown.
+	if r.clientSet == nil || r.configUnknown || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
 		return
 	}
 
-	env, name := cache.SplitMetaNamespaceKey(req.ID)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+	var plan armadaSetModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !conv.IsKnown(plan.Environment) {
+		return
+	}
+	for i, reg := range plan.Regions {
+		if !conv.IsKnown(reg.Name) {
+			continue
+		}
+		p := path.Root("regions").AtListIndex(i).AtName("replicas")
+		resp.Diagnostics.Append(validateRegionTypes(ctx, r.clientSet, r.lists, plan.Environment.ValueString(), reg.Name.ValueString(), reg.Replicas, p)...)
+	}
+}
+
+// dryRunPlan validates the planned ArmadaSet with a server-side dry run.
+func (r *armadaSet) dryRunPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	dryrun.ModifyPlan(ctx, r.dryRun, dryrun.Resource[*armadav1.ArmadaSet, armadaSetModel]{
+		Kind: "ArmadaSet",
+		Client: func(plan armadaSetModel) dryrun.Client[*armadav1.ArmadaSet] {
+			return r.clientSet.ArmadaV1().ArmadaSets(plan.Environment.ValueString())
+		},
+		Name:     func(m armadaSetModel) string { return m.Name.ValueString() },
+		ToObject: armadaSetModel.ToObject,
+		Prepare: func(_ context.Context, _ resource.ModifyPlanRequest, _, state *armadaSetModel) diag.Diagnostics {
+			if state != nil && state.Autoscaling != nil {
+				// As in Update, the global scale to zero setting must not be populated from the state.
+				state.Autoscaling.ScaleToZero = nil
+			}
+			return nil
+		},
+	}, req, resp)
 }
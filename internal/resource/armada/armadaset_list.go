@@ -0,0 +1,39 @@
+package armada
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	armadav1 "github.com/gamefabric/gf-core/pkg/api/armada/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewArmadaSetList returns a new instance of the ArmadaSet list resource.
+func NewArmadaSetList() list.ListResource {
+	return listresource.NewNamespaced(listresource.Options[armadav1.ArmadaSet]{
+		TypeName: "armadaset",
+		Plural:   "armadasets",
+		Kinds:    "ArmadaSets",
+		List: func(ctx context.Context, cs clientset.Interface, env string, opts metav1.ListOptions) ([]armadav1.ArmadaSet, error) {
+			objs, err := cs.ArmadaV1().ArmadaSets(env).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *armadav1.ArmadaSet, defaults listresource.Defaults) listresource.Result {
+			m := newArmadaSetModel(obj, nil)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Namespaced{Environment: m.Environment, Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/core"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -20,6 +21,8 @@ type armadaSetModel struct {
 	Description           types.String                       `tfsdk:"description"`
 	Labels                map[string]types.String            `tfsdk:"labels"`
 	Annotations           map[string]types.String            `tfsdk:"annotations"`
+	LabelsAll             map[string]types.String            `tfsdk:"labels_all"`
+	AnnotationsAll        map[string]types.String            `tfsdk:"annotations_all"`
 	Autoscaling           *armadaSetAutoscalingModel         `tfsdk:"autoscaling"`
 	Regions               []regionModel                      `tfsdk:"regions"`
 	GameServerLabels      map[string]types.String            `tfsdk:"gameserver_labels"`
@@ -32,6 +35,8 @@ type armadaSetModel struct {
 	GatewayPolicies       []types.String                     `tfsdk:"gateway_policies"`
 	ProfilingEnabled      types.Bool                         `tfsdk:"profiling_enabled"`
 	ImageUpdaterTarget    *container.ImageUpdaterTargetModel `tfsdk:"image_updater_target"`
+	WaitForRollout        types.Bool                         `tfsdk:"wait_for_rollout"`
+	Timeouts              timeouts.Value                     `tfsdk:"timeouts"`
 }
 
 func newArmadaSetModel(obj *armadav1.ArmadaSet, as *armadaSetAutoscalingModel) armadaSetModel {
@@ -42,6 +47,8 @@ func newArmadaSetModel(obj *armadav1.ArmadaSet, as *armadaSetAutoscalingModel) a
 		Description:           conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
 		Labels:                conv.ForEachMapItem(obj.Labels, types.StringValue),
 		Annotations:           conv.ForEachMapItem(obj.Annotations, types.StringValue),
+		LabelsAll:             conv.ForEachMapItem(obj.Labels, types.StringValue),
+		AnnotationsAll:        conv.ForEachMapItem(obj.Annotations, types.StringValue),
 		Autoscaling:           newArmadaSetAutoscalingModel(obj.Spec.Autoscaling, as),
 		Regions:               newRegionModels(obj.Spec),
 		GameServerLabels:      conv.ForEachMapItem(conv.MapWithoutKey(obj.Spec.Template.Labels, profilingKey), types.StringValue),
@@ -54,6 +61,7 @@ func newArmadaSetModel(obj *armadav1.ArmadaSet, as *armadaSetAutoscalingModel) a
 		GatewayPolicies:       conv.ForEachSliceItem(obj.Spec.Template.Spec.GatewayPolicies, types.StringValue),
 		ProfilingEnabled:      conv.BoolFromMapKey(obj.Spec.Template.Labels, profilingKey, types.BoolValue(false)),
 		ImageUpdaterTarget:    container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeArmadaSet, obj.Name, obj.Environment),
+		Timeouts:              timeouts.Null(),
 	}
 }
 
@@ -62,8 +70,8 @@ func (m armadaSetModel) ToObject() *armadav1.ArmadaSet {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
 			Environment: m.Environment.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(v types.String) string { return v.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(v types.String) string { return v.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(v types.String) string { return v.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(v types.String) string { return v.ValueString() }),
 		},
 		Spec: armadav1.ArmadaSetSpec{
 			Description: m.Description.ValueString(),
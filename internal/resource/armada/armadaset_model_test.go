@@ -273,6 +273,12 @@ var (
 		Annotations: map[string]types.String{
 			"annotation-key": types.StringValue("annotation-value"),
 		},
+		LabelsAll: map[string]types.String{
+			"label-key": types.StringValue("label-value"),
+		},
+		AnnotationsAll: map[string]types.String{
+			"annotation-key": types.StringValue("annotation-value"),
+		},
 		Autoscaling: &armadaSetAutoscalingModel{
 			FixedIntervalSeconds: types.Int32Value(60),
 		},
@@ -409,9 +409,9 @@ func TestResourceArmadaSetConfigValidates(t *testing.T) {
 			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "name" is required`)),
 		},
 		{
-			name:        "requires environment",
-			config:      testResourceArmadaSetConfigEmpty(),
-			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "environment" is required`)),
+			name:        "requires environment without default environment",
+			config:      strings.Replace(testResourceArmadaSetConfigBasic(), `environment = "test"`, "", 1),
+			expectError: regexp.MustCompile(regexp.QuoteMeta(`Missing Environment`)),
 		},
 		{
 			name:        "requires containers",
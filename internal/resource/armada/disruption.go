@@ -0,0 +1,156 @@
+package armada
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	armadav1 "github.com/gamefabric/gf-core/pkg/api/armada/v1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// disruption is how an update affects the running game servers of an Armada.
+type disruption int
+
+const (
+	// inPlace updates leave the running game servers untouched.
+	inPlace disruption = iota
+	// rolling updates replace the game servers, draining allocated ones
+	// until their sessions end.
+	rolling
+	// destructive updates shut down all game servers, including allocated ones.
+	destructive
+)
+
+// armadaChange describes how an update affects the running game servers of an Armada.
+type armadaChange struct {
+	Disruption disruption
+	// Attributes are the changed attributes that cause the disruption.
+	Attributes []string
+	// RegionTypes are the region types with affected game servers.
+	RegionTypes []string
+	// Unknown are the template attributes that are only known after apply.
+	// They roll out new game servers if they change.
+	Unknown []string
+	// Recreate is true if all unallocated game servers are replaced at once.
+	Recreate bool
+}
+
+// disruptiveAttributes are the attributes whose changes roll out new game servers.
+var disruptiveAttributes = []string{
+	"containers",
+	"strategy",
+	"health_checks",
+	"termination_configuration",
+	"volumes",
+	"gateway_policies",
+	"gameserver_labels",
+	"gameserver_annotations",
+	"profiling_enabled",
+}
+
+// classifyArmadaChange classifies the update from oldObj to newObj.
+// replace holds the attributes that force the Armada to be replaced and unknown
+// the template attributes that are only known after apply. The values of unknown
+// attributes in newObj are not compared.
+func classifyArmadaChange(oldObj, newObj *armadav1.Armada, replace, unknown []string) armadaChange {
+	regionTypes := make([]string, 0, len(oldObj.Spec.Distribution))
+	for _, rt := range oldObj.Spec.Distribution {
+		if !slices.Contains(regionTypes, rt.Name) {
+			regionTypes = append(regionTypes, rt.Name)
+		}
+	}
+
+	if len(replace) > 0 {
+		return armadaChange{Disruption: destructive, Attributes: replace, RegionTypes: regionTypes}
+	}
+
+	attrs := templateChanges(oldObj.Spec.Template, newObj.Spec.Template, unknown)
+	if len(attrs) == 0 && len(unknown) == 0 {
+		return armadaChange{Disruption: inPlace}
+	}
+	return armadaChange{
+		Disruption:  rolling,
+		Attributes:  attrs,
+		RegionTypes: regionTypes,
+		Unknown:     unknown,
+		Recreate:    newObj.Spec.Template.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType,
+	}
+}
+
+// templateChanges returns the attributes of the game server template that differ
+// between o and n, skipping the unknown ones. Changing any of them
+// rolls out new game servers.
+func templateChanges(o, n armadav1.FleetTemplateSpec, unknown []string) []string {
+	var attrs []string
+	changed := func(attr string, a, b any) {
+		if !slices.Contains(unknown, attr) && !equality.Semantic.DeepEqual(a, b) {
+			attrs = append(attrs, attr)
+		}
+	}
+	changed("containers", o.Spec.Containers, n.Spec.Containers)
+	changed("strategy", o.Spec.Strategy, n.Spec.Strategy)
+	changed("health_checks", o.Spec.Health, n.Spec.Health)
+	changed("termination_configuration", o.Spec.TerminationGracePeriodSeconds, n.Spec.TerminationGracePeriodSeconds)
+	changed("volumes", o.Spec.Volumes, n.Spec.Volumes)
+	changed("gateway_policies", o.Spec.GatewayPolicies, n.Spec.GatewayPolicies)
+	changed("gameserver_labels", conv.MapWithoutKey(o.Labels, profilingKey), conv.MapWithoutKey(n.Labels, profilingKey))
+	changed("gameserver_annotations", o.Annotations, n.Annotations)
+	changed("profiling_enabled", o.Labels[profilingKey], n.Labels[profilingKey])
+	return attrs
+}
+
+// allocatedReplicas returns the number of allocated game servers of obj
+// in the given region types.
+func allocatedReplicas(obj *armadav1.Armada, regionTypes []string) int32 {
+	var n int32
+	for _, st := range obj.Status.RegionTypes {
+		if slices.Contains(regionTypes, st.Name) {
+			n += st.AllocatedReplicas
+		}
+	}
+	return n
+}
+
+// diagnostics returns the warning for c. allocated is the number of affected
+// allocated game servers, or negative if it is unknown.
+func (c armadaChange) diagnostics(allocated int32) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if c.Disruption == inPlace {
+		return diags
+	}
+
+	attrs := strings.Join(c.Attributes, ", ")
+	regionTypes := strings.Join(c.RegionTypes, ", ")
+
+	var summary, detail string
+	switch {
+	case c.Disruption == destructive:
+		summary = "Destructive Armada Update"
+		detail = fmt.Sprintf("Changing %s replaces the Armada. All of its game servers in region types %s are shut down, "+
+			"including allocated ones.", attrs, regionTypes)
+	case len(c.Attributes) == 0:
+		summary = "Possible Rolling Armada Update"
+		detail = fmt.Sprintf("%s are only known after apply. If they change, new game servers are rolled out "+
+			"in region types %s. Allocated game servers are drained and shut down once their sessions end.",
+			strings.Join(c.Unknown, ", "), regionTypes)
+	default:
+		summary = "Rolling Armada Update"
+		detail = fmt.Sprintf("Changing %s rolls out new game servers in region types %s. "+
+			"Allocated game servers are drained and shut down once their sessions end.", attrs, regionTypes)
+		if len(c.Unknown) > 0 {
+			detail += fmt.Sprintf(" %s are only known after apply.", strings.Join(c.Unknown, ", "))
+		}
+		if c.Recreate {
+			detail += " With the recreate strategy, all unallocated game servers are shut down at once."
+		}
+	}
+	if allocated >= 0 {
+		detail += fmt.Sprintf(" %d allocated game servers are affected.", allocated)
+	}
+	diags.AddWarning(summary, detail)
+	return diags
+}
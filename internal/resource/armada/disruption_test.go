@@ -0,0 +1,155 @@
+package armada
+
+import (
+	"testing"
+
+	armadav1 "github.com/gamefabric/gf-core/pkg/api/armada/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestClassifyArmadaChange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		update  func(obj *armadav1.Armada)
+		replace []string
+		unknown []string
+		want    armadaChange
+	}{
+		{
+			name: "in place",
+			update: func(obj *armadav1.Armada) {
+				obj.Spec.Description = "new"
+				obj.Spec.Distribution[0].MinReplicas = 5
+			},
+			want: armadaChange{Disruption: inPlace},
+		},
+		{
+			name: "rolling",
+			update: func(obj *armadav1.Armada) {
+				obj.Spec.Template.Spec.Containers[0].Image = "game:2"
+				obj.Spec.Template.Labels = map[string]string{"team": "a"}
+			},
+			want: armadaChange{
+				Disruption:  rolling,
+				Attributes:  []string{"containers", "gameserver_labels"},
+				RegionTypes: []string{"baremetal", "cloud"},
+			},
+		},
+		{
+			name: "recreate strategy",
+			update: func(obj *armadav1.Armada) {
+				obj.Spec.Template.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+			},
+			want: armadaChange{
+				Disruption:  rolling,
+				Attributes:  []string{"strategy"},
+				RegionTypes: []string{"baremetal", "cloud"},
+				Recreate:    true,
+			},
+		},
+		{
+			name: "unknown",
+			update: func(obj *armadav1.Armada) {
+				obj.Spec.Template.Spec.Containers = nil
+			},
+			unknown: []string{"containers"},
+			want: armadaChange{
+				Disruption:  rolling,
+				RegionTypes: []string{"baremetal", "cloud"},
+				Unknown:     []string{"containers"},
+			},
+		},
+		{
+			name: "rolling and unknown",
+			update: func(obj *armadav1.Armada) {
+				obj.Spec.Template.Spec.Containers = nil
+				obj.Spec.Template.Annotations = map[string]string{"team": "a"}
+			},
+			unknown: []string{"containers"},
+			want: armadaChange{
+				Disruption:  rolling,
+				Attributes:  []string{"gameserver_annotations"},
+				RegionTypes: []string{"baremetal", "cloud"},
+				Unknown:     []string{"containers"},
+			},
+		},
+		{
+			name: "replace",
+			update: func(obj *armadav1.Armada) {
+				obj.Spec.Region = "us"
+			},
+			replace: []string{"region"},
+			want: armadaChange{
+				Disruption:  destructive,
+				Attributes:  []string{"region"},
+				RegionTypes: []string{"baremetal", "cloud"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			oldObj, newObj := testArmada(), testArmada()
+			test.update(newObj)
+
+			got := classifyArmadaChange(oldObj, newObj, test.replace, test.unknown)
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestArmadaChange_Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	change := armadaChange{
+		Disruption:  rolling,
+		Attributes:  []string{"containers"},
+		RegionTypes: []string{"baremetal"},
+	}
+
+	got := change.diagnostics(3)
+
+	assert.Equal(t, 1, got.WarningsCount())
+	assert.Equal(t, "Rolling Armada Update", got[0].Summary())
+	assert.Contains(t, got[0].Detail(), "baremetal")
+	assert.Contains(t, got[0].Detail(), "3 allocated game servers are affected.")
+}
+
+func TestArmadaChange_DiagnosticsUnknown(t *testing.T) {
+	t.Parallel()
+
+	change := armadaChange{
+		Disruption:  rolling,
+		RegionTypes: []string{"baremetal"},
+		Unknown:     []string{"containers"},
+	}
+
+	got := change.diagnostics(-1)
+
+	assert.Equal(t, 1, got.WarningsCount())
+	assert.Equal(t, "Possible Rolling Armada Update", got[0].Summary())
+	assert.Contains(t, got[0].Detail(), "containers are only known after apply.")
+}
+
+func testArmada() *armadav1.Armada {
+	return &armadav1.Armada{
+		Spec: armadav1.ArmadaSpec{
+			Region: "eu",
+			Distribution: []armadav1.ArmadaRegionType{
+				{Name: "baremetal", MinReplicas: 1},
+				{Name: "cloud"},
+			},
+			Template: armadav1.FleetTemplateSpec{
+				Spec: armadav1.FleetSpec{
+					Containers: []armadav1.Container{{Name: "game", Image: "game:1"}},
+				},
+			},
+		},
+	}
+}
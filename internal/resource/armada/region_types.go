@@ -0,0 +1,62 @@
+package armada
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// validateRegionTypes reads the regions of env through lists and returns a warning for
+// each replica at p with a region type the region does not define. Region types may be
+// added in the same apply, so they are not reported as errors. Nothing is reported for
+// a region that does not exist yet.
+func validateRegionTypes(ctx context.Context, cs clientset.Interface, lists *listcache.Cache, env, region string, replicas []replicaModel, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	items, err := listcache.Get(ctx, lists, listcache.Key{Kind: "Region", Environment: env}, func(ctx context.Context) ([]corev1.Region, error) {
+		list, err := cs.CoreV1().Regions(env).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	})
+	if err != nil {
+		diags.AddWarning(
+			"Could Not Validate Region Types",
+			fmt.Sprintf("Could not read Region %q to check its region types: %v. "+
+				"An unknown region type is only reported during apply.", region, err),
+		)
+		return diags
+	}
+	idx := slices.IndexFunc(items, func(obj corev1.Region) bool { return obj.Name == region })
+	if idx < 0 {
+		return diags
+	}
+
+	defined := make([]string, 0, len(items[idx].Spec.Types))
+	for _, typ := range items[idx].Spec.Types {
+		defined = append(defined, typ.Name)
+	}
+	for i, rep := range replicas {
+		if !conv.IsKnown(rep.RegionType) || slices.Contains(defined, rep.RegionType.ValueString()) {
+			continue
+		}
+		diags.AddAttributeWarning(
+			p.AtListIndex(i).AtName("region_type"),
+			"Unknown Region Type",
+			fmt.Sprintf("Region %q does not define the region type %q. Its region types are: %s. "+
+				"The apply fails unless the region type is added first.",
+				region, rep.RegionType.ValueString(), strings.Join(defined, ", ")),
+		)
+	}
+	return diags
+}
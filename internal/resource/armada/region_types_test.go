@@ -0,0 +1,81 @@
+package armada
+
+import (
+	"context"
+	"testing"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/fake"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRegionTypes(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New(&corev1.Region{
+		ObjectMeta: metav1.ObjectMeta{Name: "eu", Environment: "dflt"},
+		Spec: corev1.RegionSpec{
+			Types: []corev1.RegionType{{Name: "baremetal"}, {Name: "cloud"}},
+		},
+	})
+	require.NoError(t, err)
+
+	replicas := []replicaModel{
+		{RegionType: types.StringValue("baremetal")},
+		{RegionType: types.StringValue("gpu")},
+		{RegionType: types.StringUnknown()},
+	}
+
+	got := validateRegionTypes(t.Context(), cs, listcache.New(), "dflt", "eu", replicas, path.Root("replicas"))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, diag.SeverityWarning, got[0].Severity())
+	assert.Equal(t, "Unknown Region Type", got[0].Summary())
+	assert.Equal(t, `Region "eu" does not define the region type "gpu". Its region types are: baremetal, cloud. `+
+		"The apply fails unless the region type is added first.", got[0].Detail())
+	pd, ok := got[0].(interface{ Path() path.Path })
+	require.True(t, ok)
+	assert.Equal(t, path.Root("replicas").AtListIndex(1).AtName("region_type"), pd.Path())
+}
+
+func TestValidateRegionTypes_RegionNotFound(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New()
+	require.NoError(t, err)
+
+	replicas := []replicaModel{{RegionType: types.StringValue("gpu")}}
+
+	got := validateRegionTypes(t.Context(), cs, listcache.New(), "dflt", "eu", replicas, path.Root("replicas"))
+
+	assert.Empty(t, got)
+}
+
+func TestValidateRegionTypes_UsesListCache(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New()
+	require.NoError(t, err)
+
+	lists := listcache.New()
+	_, err = listcache.Get(t.Context(), lists, listcache.Key{Kind: "Region", Environment: "dflt"}, func(context.Context) ([]corev1.Region, error) {
+		return []corev1.Region{{
+			ObjectMeta: metav1.ObjectMeta{Name: "eu", Environment: "dflt"},
+			Spec:       corev1.RegionSpec{Types: []corev1.RegionType{{Name: "baremetal"}}},
+		}}, nil
+	})
+	require.NoError(t, err)
+
+	replicas := []replicaModel{{RegionType: types.StringValue("gpu")}}
+
+	got := validateRegionTypes(t.Context(), cs, lists, "dflt", "eu", replicas, path.Root("replicas"))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "Unknown Region Type", got[0].Summary())
+}
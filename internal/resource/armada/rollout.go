@@ -0,0 +1,58 @@
+package armada
+
+import (
+	armadav1 "github.com/gamefabric/gf-core/pkg/api/armada/v1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
+)
+
+// armadaRolloutStatus returns the rollout state of an Armada by region type.
+func armadaRolloutStatus(obj *armadav1.Armada) wait.RolloutStatus {
+	ready := make(map[string]int32, len(obj.Status.RegionTypes))
+	for _, st := range obj.Status.RegionTypes {
+		ready[st.Name] = st.ReadyReplicas
+	}
+	return wait.RolloutStatus{
+		Generation:         obj.Generation,
+		ObservedGeneration: obj.Status.ObservedGeneration,
+		ReadyReplicas:      ready,
+	}
+}
+
+// armadaSetRolloutStatus returns the rollout state of an ArmadaSet by region and region type.
+func armadaSetRolloutStatus(obj *armadav1.ArmadaSet) wait.RolloutStatus {
+	ready := map[string]int32{}
+	for _, reg := range obj.Status.Regions {
+		for _, st := range reg.RegionTypes {
+			ready[rolloutGroup(reg.Name, st.Name)] = st.ReadyReplicas
+		}
+	}
+	return wait.RolloutStatus{
+		Generation:         obj.Generation,
+		ObservedGeneration: obj.Status.ObservedGeneration,
+		ReadyReplicas:      ready,
+	}
+}
+
+// minReadyReplicas returns the minimum replicas of each region type.
+func minReadyReplicas(replicas []replicaModel) map[string]int32 {
+	minReady := make(map[string]int32, len(replicas))
+	for _, r := range replicas {
+		minReady[r.RegionType.ValueString()] = r.MinReplicas.ValueInt32()
+	}
+	return minReady
+}
+
+// minReadyReplicasByRegion returns the minimum replicas of each region type in each region.
+func minReadyReplicasByRegion(regions []regionModel) map[string]int32 {
+	minReady := map[string]int32{}
+	for _, reg := range regions {
+		for typ, n := range minReadyReplicas(reg.Replicas) {
+			minReady[rolloutGroup(reg.Name.ValueString(), typ)] = n
+		}
+	}
+	return minReady
+}
+
+func rolloutGroup(region, regionType string) string {
+	return region + "/" + regionType
+}
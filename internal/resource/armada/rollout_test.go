@@ -0,0 +1,37 @@
+package armada
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinReadyReplicasByRegion(t *testing.T) {
+	t.Parallel()
+
+	regions := []regionModel{
+		{
+			Name: types.StringValue("eu"),
+			Replicas: []replicaModel{
+				{RegionType: types.StringValue("baremetal"), MinReplicas: types.Int32Value(2)},
+				{RegionType: types.StringValue("cloud"), MinReplicas: types.Int32Value(0)},
+			},
+		},
+		{
+			Name: types.StringValue("us"),
+			Replicas: []replicaModel{
+				{RegionType: types.StringValue("baremetal"), MinReplicas: types.Int32Value(1)},
+			},
+		},
+	}
+
+	got := minReadyReplicasByRegion(regions)
+
+	want := map[string]int32{
+		"eu/baremetal": 2,
+		"eu/cloud":     0,
+		"us/baremetal": 1,
+	}
+	assert.Equal(t, want, got)
+}
@@ -4,14 +4,16 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	auditv1alpha1 "github.com/gamefabric/gf-core/pkg/api/audit/v1alpha1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
@@ -30,6 +32,7 @@ var (
 	_ resource.Resource                = &exportStore{}
 	_ resource.ResourceWithConfigure   = &exportStore{}
 	_ resource.ResourceWithImportState = &exportStore{}
+	_ resource.ResourceWithIdentity    = &exportStore{}
 )
 
 type exportStore struct {
@@ -149,6 +152,11 @@ func (r *exportStore) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *exportStore) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *exportStore) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -186,6 +194,7 @@ func (r *exportStore) Create(ctx context.Context, req resource.CreateRequest, re
 		obj.Spec.S3.Auth.SecretAccessKey = config.S3.Auth.SecretAccessKey.ValueString()
 	}
 
+	logging.Object(ctx, "ExportStore", obj)
 	outObj, err := r.clientSet.AuditV1Alpha1().ExportStores().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -200,6 +209,8 @@ func (r *exportStore) Create(ctx context.Context, req resource.CreateRequest, re
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *exportStore) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -236,6 +247,8 @@ func (r *exportStore) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 func (r *exportStore) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -280,18 +293,31 @@ func (r *exportStore) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	if _, err = r.clientSet.AuditV1Alpha1().ExportStores().Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "ExportStore", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.AuditV1Alpha1().ExportStores(), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("ExportStore", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching ExportStore",
 			fmt.Sprintf("Could not patch ExportStore: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
-	preserveWriteOnly(&plan, config, plan.S3.Auth.SecretAccessKeyVersion)
+	var version types.Int64
+	if plan.S3 != nil {
+		version = plan.S3.Auth.SecretAccessKeyVersion
+	}
+	plan = newExportStoreModel(outObj)
+	preserveWriteOnly(&plan, config, version)
 
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *exportStore) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -319,7 +345,7 @@ func (r *exportStore) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *exportStore) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
 
 // preserveWriteOnly zeroes out the write-only secret_access_key in the model
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"regexp"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,8 +13,11 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	providerreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/authentication/provider"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -33,6 +35,7 @@ var (
 	_ resource.Resource                = &provider{}
 	_ resource.ResourceWithConfigure   = &provider{}
 	_ resource.ResourceWithImportState = &provider{}
+	_ resource.ResourceWithIdentity    = &provider{}
 )
 
 var providerValidator = validators.NewGameFabricValidator[*authenticationv1.Provider, providerModel](func() validators.StoreValidator {
@@ -324,6 +327,11 @@ func (r *provider) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *provider) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *provider) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -351,6 +359,7 @@ func (r *provider) Create(ctx context.Context, req resource.CreateRequest, resp
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "Provider", obj)
 	outObj, err := r.clientSet.AuthenticationV1Beta1().Providers().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -362,6 +371,8 @@ func (r *provider) Create(ctx context.Context, req resource.CreateRequest, resp
 	plan = newProviderModel(outObj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -389,6 +400,8 @@ func (r *provider) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	state = newProviderModel(obj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, obj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -415,16 +428,25 @@ func (r *provider) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	if _, err = r.clientSet.AuthenticationV1Beta1().Providers().Patch(ctx, state.Name.ValueString(), rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Provider", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.AuthenticationV1Beta1().Providers(), state.Name.ValueString(), pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Provider", state.Name.ValueString()))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Patching Provider",
 			fmt.Sprintf("Could not patch Authentication Provider %q: %v", state.Name.ValueString(), err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	plan = newProviderModel(outObj)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *provider) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -451,5 +473,5 @@ func (r *provider) Delete(ctx context.Context, req resource.DeleteRequest, resp
 }
 
 func (r *provider) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
@@ -4,15 +4,18 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -27,11 +30,14 @@ var (
 	_ resource.Resource                = &serviceAccount{}
 	_ resource.ResourceWithConfigure   = &serviceAccount{}
 	_ resource.ResourceWithImportState = &serviceAccount{}
+	_ resource.ResourceWithModifyPlan  = &serviceAccount{}
+	_ resource.ResourceWithIdentity    = &serviceAccount{}
 )
 
 // serviceAccount implements the Terraform resource for service accounts.
 type serviceAccount struct {
-	clientSet clientset.Interface
+	clientSet     clientset.Interface
+	defaultLabels types.Map
 }
 
 // NewServiceAccountResource creates a new instance of the service account resource.
@@ -68,6 +74,11 @@ func (r *serviceAccount) Schema(_ context.Context, _ resource.SchemaRequest, res
 					mapplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "All labels of the service account, including the provider's default_labels.",
+			},
 			"email": schema.StringAttribute{
 				Computed:    true,
 				Description: "The email address for the service account (auto-generated).",
@@ -79,6 +90,11 @@ func (r *serviceAccount) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *serviceAccount) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 func (r *serviceAccount) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -92,6 +108,7 @@ func (r *serviceAccount) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 	r.clientSet = procCtx.ClientSet
+	r.defaultLabels = procCtx.DefaultLabels
 }
 
 func (r *serviceAccount) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -102,6 +119,7 @@ func (r *serviceAccount) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "ServiceAccount", obj)
 	created, err := r.clientSet.AuthenticationV1Beta1().ServiceAccounts().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError("Error Creating Service Account", fmt.Sprintf("Could not create ServiceAccount: %s", err))
@@ -110,10 +128,13 @@ func (r *serviceAccount) Create(ctx context.Context, req resource.CreateRequest,
 
 	plan.ID = types.StringValue(created.Name)
 	plan.Email = types.StringValue(created.Spec.Email)
-	plan.Labels = conv.ForEachMapItem(created.Labels, types.StringValue)
+	plan.LabelsAll = conv.ForEachMapItem(created.Labels, types.StringValue)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, plan.Labels, r.defaultLabels)
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, created)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *serviceAccount) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -139,9 +160,12 @@ func (r *serviceAccount) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Create a new state model from the API response
 	newState := newServiceAccountResourceModel(obj)
+	newState.Labels = conv.WithoutDefaults(newState.LabelsAll, state.Labels, r.defaultLabels)
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &newState, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, obj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: newState.Name})...)
 }
 
 func (r *serviceAccount) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -167,16 +191,27 @@ func (r *serviceAccount) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	if _, err = r.clientSet.AuthenticationV1Beta1().ServiceAccounts().Patch(ctx, state.Name.ValueString(), rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "ServiceAccount", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.AuthenticationV1Beta1().ServiceAccounts(), state.Name.ValueString(), pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("ServiceAccount", state.Name.ValueString()))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Patching ServiceAccount",
 			fmt.Sprintf("Could not patch ServiceAccount %q: %v", state.Name.ValueString(), err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	labels := plan.Labels
+	plan = newServiceAccountResourceModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *serviceAccount) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -201,8 +236,14 @@ func (r *serviceAccount) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ModifyPlan merges the provider's default labels.
+func (r *serviceAccount) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+}
+
 func (r *serviceAccount) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	if req.ID == "" {
+		identity.ImportCluster(ctx, req, resp, path.Root("name"))
 		return
 	}
 
@@ -12,18 +12,20 @@ import (
 const ecDomain = "ec.nitrado.systems"
 
 type serviceAccountResourceModel struct {
-	ID     types.String            `tfsdk:"id"`
-	Name   types.String            `tfsdk:"name"`
-	Labels map[string]types.String `tfsdk:"labels"`
-	Email  types.String            `tfsdk:"email"`
+	ID        types.String            `tfsdk:"id"`
+	Name      types.String            `tfsdk:"name"`
+	Labels    map[string]types.String `tfsdk:"labels"`
+	LabelsAll map[string]types.String `tfsdk:"labels_all"`
+	Email     types.String            `tfsdk:"email"`
 }
 
 func newServiceAccountResourceModel(obj *authv1.ServiceAccount) serviceAccountResourceModel {
 	return serviceAccountResourceModel{
-		ID:     types.StringValue(obj.Name),
-		Name:   types.StringValue(obj.Name),
-		Labels: toStringMap(obj.Labels),
-		Email:  types.StringValue(obj.Spec.Email),
+		ID:        types.StringValue(obj.Name),
+		Name:      types.StringValue(obj.Name),
+		Labels:    toStringMap(obj.Labels),
+		LabelsAll: toStringMap(obj.Labels),
+		Email:     types.StringValue(obj.Spec.Email),
 	}
 }
 
@@ -39,7 +41,7 @@ func (m serviceAccountResourceModel) ToObject() *authv1.ServiceAccount {
 	return &authv1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   m.Name.ValueString(),
-			Labels: conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
+			Labels: conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: authv1.ServiceAccountSpec{
 			Username: m.Name.ValueString(),
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -13,8 +12,13 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	cloudbudgetreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/billing/cloudbudget"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -31,6 +35,8 @@ var (
 	_ resource.Resource                = &cloudBudget{}
 	_ resource.ResourceWithConfigure   = &cloudBudget{}
 	_ resource.ResourceWithImportState = &cloudBudget{}
+	_ resource.ResourceWithModifyPlan  = &cloudBudget{}
+	_ resource.ResourceWithIdentity    = &cloudBudget{}
 )
 
 var cloudBudgetValidator = validators.NewGameFabricValidator[*billingv2alpha1.CloudBudget, cloudBudgetModel](func() validators.StoreValidator {
@@ -41,7 +47,9 @@ var cloudBudgetValidator = validators.NewGameFabricValidator[*billingv2alpha1.Cl
 })
 
 type cloudBudget struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewCloudBudgetResource returns a new instance of the cloud budget resource.
@@ -94,6 +102,18 @@ func (r *cloudBudget) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					&validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"suspended": schema.BoolAttribute{
 				Description:         "Suspends the cloud budget and suppresses all further notifications.",
 				MarkdownDescription: "Suspends the cloud budget and suppresses all further notifications.",
@@ -159,6 +179,11 @@ func (r *cloudBudget) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *cloudBudget) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *cloudBudget) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -175,6 +200,8 @@ func (r *cloudBudget) Configure(_ context.Context, req resource.ConfigureRequest
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *cloudBudget) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -185,6 +212,7 @@ func (r *cloudBudget) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "CloudBudget", obj)
 	outObj, err := r.clientSet.BillingV2Alpha1().CloudBudgets().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -194,9 +222,14 @@ func (r *cloudBudget) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	labels, annotations := plan.Labels, plan.Annotations
 	plan = newCloudBudgetModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *cloudBudget) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -220,9 +253,14 @@ func (r *cloudBudget) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	labels, annotations := state.Labels, state.Annotations
 	state = newCloudBudgetModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 func (r *cloudBudget) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -248,16 +286,28 @@ func (r *cloudBudget) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	if _, err = r.clientSet.BillingV2Alpha1().CloudBudgets().Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "CloudBudget", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.BillingV2Alpha1().CloudBudgets(), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Cloud Budget", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Cloud Budget",
 			fmt.Sprintf("Could not patch Cloud Budget: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	labels, annotations := plan.Labels, plan.Annotations
+	plan = newCloudBudgetModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *cloudBudget) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -285,6 +335,12 @@ func (r *cloudBudget) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 }
 
+// ModifyPlan merges the provider's default labels and annotations.
+func (r *cloudBudget) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+}
+
 func (r *cloudBudget) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
@@ -9,15 +9,17 @@ import (
 )
 
 type cloudBudgetModel struct {
-	ID          types.String              `tfsdk:"id"`
-	Name        types.String              `tfsdk:"name"`
-	Labels      map[string]types.String   `tfsdk:"labels"`
-	Annotations map[string]types.String   `tfsdk:"annotations"`
-	Suspended   types.Bool                `tfsdk:"suspended"`
-	Receivers   []types.String            `tfsdk:"receivers"`
-	MaxBudget   types.Float64             `tfsdk:"max_budget"`
-	Thresholds  []types.String            `tfsdk:"thresholds"`
-	Interval    *cloudBudgetIntervalModel `tfsdk:"interval"`
+	ID             types.String              `tfsdk:"id"`
+	Name           types.String              `tfsdk:"name"`
+	Labels         map[string]types.String   `tfsdk:"labels"`
+	Annotations    map[string]types.String   `tfsdk:"annotations"`
+	LabelsAll      map[string]types.String   `tfsdk:"labels_all"`
+	AnnotationsAll map[string]types.String   `tfsdk:"annotations_all"`
+	Suspended      types.Bool                `tfsdk:"suspended"`
+	Receivers      []types.String            `tfsdk:"receivers"`
+	MaxBudget      types.Float64             `tfsdk:"max_budget"`
+	Thresholds     []types.String            `tfsdk:"thresholds"`
+	Interval       *cloudBudgetIntervalModel `tfsdk:"interval"`
 }
 
 type cloudBudgetIntervalModel struct {
@@ -35,15 +37,17 @@ func newCloudBudgetModel(obj *billingv2alpha1.CloudBudget) cloudBudgetModel {
 	}
 
 	return cloudBudgetModel{
-		ID:          types.StringValue(obj.Name),
-		Name:        types.StringValue(obj.Name),
-		Labels:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
-		Annotations: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
-		Suspended:   types.BoolValue(obj.Spec.Suspended),
-		Receivers:   conv.ForEachSliceItem(obj.Spec.Receivers, func(item string) types.String { return types.StringValue(item) }),
-		MaxBudget:   types.Float64Value(obj.Spec.MaxBudget),
-		Thresholds:  conv.ForEachSliceItem(obj.Spec.Thresholds, func(item intstr.IntOrString) types.String { return conv.FromIntOrString(&item) }),
-		Interval:    interval,
+		ID:             types.StringValue(obj.Name),
+		Name:           types.StringValue(obj.Name),
+		Labels:         conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		Annotations:    conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		LabelsAll:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		AnnotationsAll: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		Suspended:      types.BoolValue(obj.Spec.Suspended),
+		Receivers:      conv.ForEachSliceItem(obj.Spec.Receivers, func(item string) types.String { return types.StringValue(item) }),
+		MaxBudget:      types.Float64Value(obj.Spec.MaxBudget),
+		Thresholds:     conv.ForEachSliceItem(obj.Spec.Thresholds, func(item intstr.IntOrString) types.String { return conv.FromIntOrString(&item) }),
+		Interval:       interval,
 	}
 }
 
@@ -61,8 +65,8 @@ func (m cloudBudgetModel) ToObject() *billingv2alpha1.CloudBudget {
 	return &billingv2alpha1.CloudBudget{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: billingv2alpha1.CloudBudgetSpec{
 			Suspended: m.Suspended.ValueBool(),
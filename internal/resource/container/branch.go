@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -13,8 +12,13 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	branchreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/container/branch"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -31,6 +35,8 @@ var (
 	_ resource.Resource                = &branch{}
 	_ resource.ResourceWithConfigure   = &branch{}
 	_ resource.ResourceWithImportState = &branch{}
+	_ resource.ResourceWithModifyPlan  = &branch{}
+	_ resource.ResourceWithIdentity    = &branch{}
 )
 
 var branchValidator = validators.NewGameFabricValidator[*containerv1.Branch, branchModel](func() validators.StoreValidator {
@@ -42,7 +48,9 @@ var branchValidator = validators.NewGameFabricValidator[*containerv1.Branch, bra
 
 // branch is the branch resource.
 type branch struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewBranch creates a new branch resource.
@@ -100,6 +108,18 @@ func (r *branch) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"description": schema.StringAttribute{
 				Description:         "Description is the optional description of the branch.",
 				MarkdownDescription: "Description is the optional description of the branch.",
@@ -161,6 +181,11 @@ func (r *branch) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *branch) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *branch) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -177,6 +202,8 @@ func (r *branch) Configure(_ context.Context, req resource.ConfigureRequest, res
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 // Create creates the resource and sets the initial Terraform state on success.
@@ -188,6 +215,7 @@ func (r *branch) Create(ctx context.Context, req resource.CreateRequest, resp *r
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "Branch", obj)
 	outObj, err := r.clientSet.ContainerV1().Branches().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -196,9 +224,14 @@ func (r *branch) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		)
 		return
 	}
+	labels, annotations := plan.Labels, plan.Annotations
 	plan = newBranchModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -223,9 +256,14 @@ func (r *branch) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 		return
 	}
 
+	labels, annotations := state.Labels, state.Annotations
 	state = newBranchModel(obj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, obj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -252,16 +290,28 @@ func (r *branch) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
-	if _, err = r.clientSet.ContainerV1().Branches().Patch(ctx, state.Name.ValueString(), rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Branch", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.ContainerV1().Branches(), state.Name.ValueString(), pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Branch", state.Name.ValueString()))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Patching Branch",
 			fmt.Sprintf("Could not patch Branch %q: %v", state.Name.ValueString(), err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	labels, annotations := plan.Labels, plan.Annotations
+	plan = newBranchModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *branch) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -287,6 +337,12 @@ func (r *branch) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 	}
 }
 
+// ModifyPlan merges the provider's default labels and annotations.
+func (r *branch) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+}
+
 func (r *branch) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
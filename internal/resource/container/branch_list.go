@@ -0,0 +1,39 @@
+package container
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	containerv1 "github.com/gamefabric/gf-core/pkg/api/container/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewBranchList returns a new instance of the Branch list resource.
+func NewBranchList() list.ListResource {
+	return listresource.NewCluster(listresource.Options[containerv1.Branch]{
+		TypeName: "branch",
+		Plural:   "branches",
+		Kinds:    "Branches",
+		List: func(ctx context.Context, cs clientset.Interface, _ string, opts metav1.ListOptions) ([]containerv1.Branch, error) {
+			objs, err := cs.ContainerV1().Branches().List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *containerv1.Branch, defaults listresource.Defaults) listresource.Result {
+			m := newBranchModel(obj)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Cluster{Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
@@ -12,6 +12,8 @@ type branchModel struct {
 	Name                 types.String                          `tfsdk:"name"`
 	Labels               map[string]types.String               `tfsdk:"labels"`
 	Annotations          map[string]types.String               `tfsdk:"annotations"`
+	LabelsAll            map[string]types.String               `tfsdk:"labels_all"`
+	AnnotationsAll       map[string]types.String               `tfsdk:"annotations_all"`
 	DisplayName          types.String                          `tfsdk:"display_name"`
 	Description          types.String                          `tfsdk:"description"`
 	RetentionPolicyRules []branchImageRetentionPolicyRuleModel `tfsdk:"retention_policy_rules"`
@@ -23,6 +25,8 @@ func newBranchModel(obj *containerv1.Branch) branchModel {
 		Name:                 types.StringValue(obj.Name),
 		Labels:               conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
 		Annotations:          conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		LabelsAll:            conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		AnnotationsAll:       conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
 		DisplayName:          types.StringValue(obj.Spec.DisplayName),
 		Description:          conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
 		RetentionPolicyRules: conv.EmptyIfNil(conv.ForEachSliceItem(obj.Spec.RetentionPolicyRules, newBranchImageRetentionPolicyRuleModel)),
@@ -33,8 +37,8 @@ func (m branchModel) ToObject() *containerv1.Branch {
 	return &containerv1.Branch{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: containerv1.BranchSpec{
 			DisplayName: m.DisplayName.ValueString(),
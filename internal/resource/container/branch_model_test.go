@@ -61,6 +61,12 @@ var (
 		Annotations: map[string]types.String{
 			"annotation-key": types.StringValue("annotation-value"),
 		},
+		LabelsAll: map[string]types.String{
+			"label-key": types.StringValue("label-value"),
+		},
+		AnnotationsAll: map[string]types.String{
+			"annotation-key": types.StringValue("annotation-value"),
+		},
 		DisplayName: types.StringValue("Test Branch Display Name"),
 		Description: types.StringValue("Test Branch Description"),
 		RetentionPolicyRules: []branchImageRetentionPolicyRuleModel{
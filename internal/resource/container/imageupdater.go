@@ -4,18 +4,21 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -25,12 +28,14 @@ import (
 )
 
 var (
-	_ resource.Resource              = &imageUpdater{}
-	_ resource.ResourceWithConfigure = &imageUpdater{}
+	_ resource.Resource               = &imageUpdater{}
+	_ resource.ResourceWithConfigure  = &imageUpdater{}
+	_ resource.ResourceWithModifyPlan = &imageUpdater{}
 )
 
 type imageUpdater struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	defaultEnvironment types.String
 }
 
 // NewImageUpdater returns a new instance of the image updater resource.
@@ -86,14 +91,15 @@ func (r *imageUpdater) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						},
 					},
 					"environment": schema.StringAttribute{
-						Description:         "The environment in which the target resource operates.",
-						MarkdownDescription: "The environment in which the target resource operates.",
-						Required:            true,
+						Description:         "The environment in which the target resource operates. Defaults to the provider's default_environment.",
+						MarkdownDescription: "The environment in which the target resource operates. Defaults to the provider's `default_environment`.",
+						Optional:            true,
+						Computed:            true,
 						Validators: []validator.String{
 							validators.EnvironmentValidator{},
 						},
 						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
+							stringplanmodifier.RequiresReplaceIfConfigured(),
 						},
 					},
 				},
@@ -118,6 +124,7 @@ func (r *imageUpdater) Configure(_ context.Context, req resource.ConfigureReques
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.defaultEnvironment = procCtx.DefaultEnvironment
 }
 
 func (r *imageUpdater) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -128,6 +135,7 @@ func (r *imageUpdater) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	obj := plan.ToObject(uuid.New().String())
+	logging.Object(ctx, "ImageUpdater", obj)
 	outObj, err := r.clientSet.ContainerV1().ImageUpdaters(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -140,6 +148,7 @@ func (r *imageUpdater) Create(ctx context.Context, req resource.CreateRequest, r
 	plan = newImageUpdaterModel(outObj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 }
 
 func (r *imageUpdater) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -167,6 +176,7 @@ func (r *imageUpdater) Read(ctx context.Context, req resource.ReadRequest, resp
 	state = newImageUpdaterModel(outObj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 }
 
 func (r *imageUpdater) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -194,15 +204,23 @@ func (r *imageUpdater) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	if _, err = r.clientSet.ContainerV1().ImageUpdaters(env).Patch(ctx, name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "ImageUpdater", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.ContainerV1().ImageUpdaters(env), name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Image Updater", name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Image Updater",
 			fmt.Sprintf("Could not patch ImageUpdater: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
+	plan = newImageUpdaterModel(outObj)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -231,3 +249,8 @@ func (r *imageUpdater) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 }
+
+// ModifyPlan fills in the provider's default environment when none is configured.
+func (r *imageUpdater) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("target").AtName("environment"), r.defaultEnvironment, req, resp)
+}
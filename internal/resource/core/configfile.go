@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
-	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,8 +12,13 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	configfilereg "github.com/gamefabric/gf-core/pkg/apiserver/registry/core/configfile"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -31,6 +34,8 @@ var (
 	_ resource.Resource                = &configFile{}
 	_ resource.ResourceWithConfigure   = &configFile{}
 	_ resource.ResourceWithImportState = &configFile{}
+	_ resource.ResourceWithModifyPlan  = &configFile{}
+	_ resource.ResourceWithIdentity    = &configFile{}
 )
 
 var configFileValidator = validators.NewGameFabricValidator[*corev1.ConfigFile, configFileModel](func() validators.StoreValidator {
@@ -41,7 +46,10 @@ var configFileValidator = validators.NewGameFabricValidator[*corev1.ConfigFile,
 })
 
 type configFile struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	defaultEnvironment types.String
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewConfigFile returns a new instance of the config file resource.
@@ -76,14 +84,15 @@ func (r *configFile) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 				},
 			},
 			"environment": schema.StringAttribute{
-				Description:         "The name of the environment the resource belongs to.",
-				MarkdownDescription: "The name of the environment the resource belongs to.",
-				Required:            true,
+				Description:         "The name of the environment the resource belongs to. Defaults to the provider's default_environment.",
+				MarkdownDescription: "The name of the environment the resource belongs to. Defaults to the provider's `default_environment`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					validators.EnvironmentValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"labels": schema.MapAttribute{
@@ -104,6 +113,18 @@ func (r *configFile) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"description": schema.StringAttribute{
 				Description:         "Description is the optional description of the config file.",
 				MarkdownDescription: "Description is the optional description of the config file.",
@@ -121,6 +142,11 @@ func (r *configFile) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *configFile) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *configFile) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -137,6 +163,9 @@ func (r *configFile) Configure(_ context.Context, req resource.ConfigureRequest,
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *configFile) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -147,6 +176,7 @@ func (r *configFile) Create(ctx context.Context, req resource.CreateRequest, res
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "ConfigFile", obj)
 	outObj, err := r.clientSet.CoreV1().ConfigFiles(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -156,9 +186,14 @@ func (r *configFile) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	labels, annotations := plan.Labels, plan.Annotations
 	plan = newConfigModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
 }
 
 func (r *configFile) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -182,9 +217,14 @@ func (r *configFile) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		return
 	}
 
+	labels, annotations := state.Labels, state.Annotations
 	state = newConfigModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.Name})...)
 }
 
 func (r *configFile) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -210,16 +250,28 @@ func (r *configFile) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
-	if _, err = r.clientSet.CoreV1().ConfigFiles(newObj.Environment).Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "ConfigFile", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.CoreV1().ConfigFiles(newObj.Environment), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Config File", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Config File",
 			fmt.Sprintf("Could not patch ConfigFile: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
+	labels, annotations := plan.Labels, plan.Annotations
+	plan = newConfigModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
 }
 
 func (r *configFile) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -247,12 +299,14 @@ func (r *configFile) Delete(ctx context.Context, req resource.DeleteRequest, res
 	}
 }
 
-func (r *configFile) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if req.ID == "" {
-		return
-	}
+// ModifyPlan fills in the provider's default environment when none is configured
+// and merges the provider's default labels and annotations.
+func (r *configFile) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("environment"), r.defaultEnvironment, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+}
 
-	env, name := cache.SplitMetaNamespaceKey(req.ID)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+func (r *configFile) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	identity.ImportNamespaced(ctx, req, resp, path.Root("name"), r.defaultEnvironment)
 }
@@ -45,6 +45,12 @@ var (
 		Annotations: map[string]types.String{
 			"annotation-key": types.StringValue("annotation-value"),
 		},
+		LabelsAll: map[string]types.String{
+			"label-key": types.StringValue("label-value"),
+		},
+		AnnotationsAll: map[string]types.String{
+			"annotation-key": types.StringValue("annotation-value"),
+		},
 		Description: types.StringValue("ConfigFile Description"),
 		Data:        types.StringValue("config file data content"),
 	}
@@ -49,6 +49,73 @@ func TestConfigFile(t *testing.T) {
 	})
 }
 
+func TestConfigFileDefaultLabels(t *testing.T) {
+	t.Parallel()
+
+	name := "test-config-file"
+	pf, cs := providertest.ProtoV6ProviderFactories(t)
+
+	provider := func(team string) string {
+		return fmt.Sprintf(`provider "gamefabric" {
+  default_labels = {
+    team  = "%s"
+    owner = "infra"
+  }
+}
+`, team)
+	}
+	config := `resource "gamefabric_configfile" "test" {
+  name = "test-config-file"
+  environment = "dflt"
+  labels = {
+    owner = "me"
+  }
+  data = "config file data"
+}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: pf,
+		CheckDestroy:             testResourceConfigFileDestroy(t, cs),
+		Steps: []resource.TestStep{
+			{
+				Config: provider("core") + config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels.owner", "me"),
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels_all.%", "2"),
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels_all.owner", "me"),
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels_all.team", "core"),
+					func(*terraform.State) error {
+						obj, err := cs.CoreV1().ConfigFiles("dflt").Get(t.Context(), name, metav1.GetOptions{})
+						if err != nil {
+							return err
+						}
+						if obj.Labels["team"] != "core" || obj.Labels["owner"] != "me" {
+							return fmt.Errorf("unexpected labels: %v", obj.Labels)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "gamefabric_configfile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: provider("games") + config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels.owner", "me"),
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels_all.%", "2"),
+					resource.TestCheckResourceAttr("gamefabric_configfile.test", "labels_all.team", "games"),
+				),
+			},
+		},
+	})
+}
+
 func testResourceConfigFileConfigBasic(name string) string {
 	return fmt.Sprintf(`resource "gamefabric_configfile" "test" {
   name = "%s"
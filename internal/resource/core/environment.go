@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/deletionprotection"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -26,10 +33,15 @@ var (
 	_ resource.Resource                = &environment{}
 	_ resource.ResourceWithConfigure   = &environment{}
 	_ resource.ResourceWithImportState = &environment{}
+	_ resource.ResourceWithModifyPlan  = &environment{}
+	_ resource.ResourceWithIdentity    = &environment{}
 )
 
 type environment struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	lists              *listcache.Cache
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewEnvironment returns a new environment resource.
@@ -43,7 +55,7 @@ func (r *environment) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for this data source.
-func (r *environment) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *environment) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Environment resource represents a logical grouping and isolation of game servers, configurations, and other related resources within GameFabric.",
 		MarkdownDescription: "Environment resource represents a logical grouping and isolation of game servers, configurations, and other related resources within GameFabric.",
@@ -82,6 +94,18 @@ func (r *environment) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"display_name": schema.StringAttribute{
 				Description:         "The user-friendly name of the environment.",
 				MarkdownDescription: "The user-friendly name of the environment.",
@@ -92,10 +116,19 @@ func (r *environment) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				MarkdownDescription: "Description is the optional description of the environment.",
 				Optional:            true,
 			},
+			"deletion_protection": deletionprotection.Attribute("Environment"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *environment) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *environment) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -112,6 +145,9 @@ func (r *environment) Configure(_ context.Context, req resource.ConfigureRequest
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *environment) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -121,7 +157,11 @@ func (r *environment) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	ctx, cancel := timeouts.Create(ctx, plan.Timeouts)
+	defer cancel()
+
 	obj := plan.ToObject()
+	logging.Object(ctx, "Environment", obj)
 	outObj, err := r.clientSet.CoreV1().Environments().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -130,10 +170,19 @@ func (r *environment) Create(ctx context.Context, req resource.CreateRequest, re
 		)
 		return
 	}
+	r.lists.Invalidate(listcache.Key{Kind: "Environment"})
 
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	deletionProtection := plan.DeletionProtection
 	plan = newEnvironmentModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.Timeouts = cfgTimeouts
+	plan.DeletionProtection = deletionProtection
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *environment) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -157,9 +206,17 @@ func (r *environment) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	labels, annotations, cfgTimeouts := state.Labels, state.Annotations, state.Timeouts
+	deletionProtection := state.DeletionProtection
 	state = newEnvironmentModel(obj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
+	state.Timeouts = cfgTimeouts
+	state.DeletionProtection = deletionProtection
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, obj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 func (r *environment) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -173,6 +230,9 @@ func (r *environment) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	ctx, cancel := timeouts.Update(ctx, plan.Timeouts)
+	defer cancel()
+
 	oldObj := state.ToObject()
 	newObj := plan.ToObject()
 
@@ -185,25 +245,45 @@ func (r *environment) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	if _, err = r.clientSet.CoreV1().Environments().Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Environment", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.CoreV1().Environments(), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Environment", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Environment",
 			fmt.Sprintf("Could not patch Environment: %v", err),
 		)
 		return
 	}
+	r.lists.Invalidate(listcache.Key{Kind: "Environment"})
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	deletionProtection := plan.DeletionProtection
+	plan = newEnvironmentModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.Timeouts = cfgTimeouts
+	plan.DeletionProtection = deletionProtection
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *environment) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state environmentModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(deletionprotection.Check("Environment", state.Name.ValueString(), state.DeletionProtection)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := timeouts.Delete(ctx, state.Timeouts)
+	defer cancel()
+
 	err := r.clientSet.CoreV1().Environments().Delete(ctx, state.Name.ValueString(), metav1.DeleteOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -212,6 +292,9 @@ func (r *environment) Delete(ctx context.Context, req resource.DeleteRequest, re
 		)
 		return
 	}
+	// Deleting the Environment also deletes its Regions.
+	r.lists.Invalidate(listcache.Key{Kind: "Environment"})
+	r.lists.Invalidate(listcache.Key{Kind: "Region", Environment: state.Name.ValueString()})
 
 	err = wait.PollUntilNotFound(ctx, r.clientSet.CoreV1().Environments(), state.Name.ValueString())
 	if err != nil {
@@ -222,6 +305,12 @@ func (r *environment) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 }
 
+// ModifyPlan merges the provider's default labels and annotations.
+func (r *environment) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+}
+
 func (r *environment) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
@@ -4,26 +4,34 @@ import (
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type environmentModel struct {
-	ID          types.String            `tfsdk:"id"`
-	Name        types.String            `tfsdk:"name"`
-	Labels      map[string]types.String `tfsdk:"labels"`
-	Annotations map[string]types.String `tfsdk:"annotations"`
-	DisplayName types.String            `tfsdk:"display_name"`
-	Description types.String            `tfsdk:"description"`
+	ID                 types.String            `tfsdk:"id"`
+	Name               types.String            `tfsdk:"name"`
+	Labels             map[string]types.String `tfsdk:"labels"`
+	Annotations        map[string]types.String `tfsdk:"annotations"`
+	LabelsAll          map[string]types.String `tfsdk:"labels_all"`
+	AnnotationsAll     map[string]types.String `tfsdk:"annotations_all"`
+	DisplayName        types.String            `tfsdk:"display_name"`
+	Description        types.String            `tfsdk:"description"`
+	DeletionProtection types.Bool              `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value          `tfsdk:"timeouts"`
 }
 
 func newEnvironmentModel(obj *corev1.Environment) environmentModel {
 	return environmentModel{
-		ID:          types.StringValue(obj.Name),
-		Name:        types.StringValue(obj.Name),
-		Labels:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
-		Annotations: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
-		DisplayName: types.StringValue(obj.Spec.DisplayName),
-		Description: conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
+		ID:             types.StringValue(obj.Name),
+		Name:           types.StringValue(obj.Name),
+		Labels:         conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		Annotations:    conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		LabelsAll:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		AnnotationsAll: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		DisplayName:    types.StringValue(obj.Spec.DisplayName),
+		Description:    conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
+		Timeouts:       timeouts.Null(),
 	}
 }
 
@@ -31,8 +39,8 @@ func (m environmentModel) ToObject() *corev1.Environment {
 	return &corev1.Environment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: corev1.EnvironmentSpec{
 			DisplayName: m.DisplayName.ValueString(),
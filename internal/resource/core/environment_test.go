@@ -2,6 +2,7 @@ package core_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -46,6 +47,38 @@ func TestEnvironment(t *testing.T) {
 	})
 }
 
+func TestEnvironment_DeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	name := "prot"
+	pf, cs := providertest.ProtoV6ProviderFactories(t)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: pf,
+		CheckDestroy:             testResourceEnvironmentDestroy(t, cs),
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceEnvironmentConfigDeletionProtection(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gamefabric_environment.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testResourceEnvironmentConfigDeletionProtection(name, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				Config: testResourceEnvironmentConfigDeletionProtection(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("gamefabric_environment.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testResourceEnvironmentConfigBasic(name string) string {
 	return fmt.Sprintf(`resource "gamefabric_environment" "test" {
   name = "%s"
@@ -64,6 +97,14 @@ func testResourceEnvironmentConfigBasicWithDescription(name string) string {
 }`, name)
 }
 
+func testResourceEnvironmentConfigDeletionProtection(name string, enabled bool) string {
+	return fmt.Sprintf(`resource "gamefabric_environment" "test" {
+  name = "%s"
+  display_name = "My Env"
+  deletion_protection = %t
+}`, name, enabled)
+}
+
 func testResourceEnvironmentDestroy(t *testing.T, cs clientset.Interface) func(s *terraform.State) error {
 	return func(s *terraform.State) error {
 		for _, rs := range s.RootModule().Resources {
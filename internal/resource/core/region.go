@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
-	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,8 +12,16 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	regionreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/core/region"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dryrun"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/listcache"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -33,6 +39,8 @@ var (
 	_ resource.Resource                = &region{}
 	_ resource.ResourceWithConfigure   = &region{}
 	_ resource.ResourceWithImportState = &region{}
+	_ resource.ResourceWithModifyPlan  = &region{}
+	_ resource.ResourceWithIdentity    = &region{}
 )
 
 var regionValidator = validators.NewGameFabricValidator[*corev1.Region, regionModel](func() validators.StoreValidator {
@@ -43,7 +51,12 @@ var regionValidator = validators.NewGameFabricValidator[*corev1.Region, regionMo
 })
 
 type region struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	lists              *listcache.Cache
+	dryRun             bool
+	defaultEnvironment types.String
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewRegion returns a new instance of the region resource.
@@ -57,7 +70,7 @@ func (r *region) Metadata(_ context.Context, req resource.MetadataRequest, resp
 }
 
 // Schema defines the schema for this data source.
-func (r *region) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *region) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -78,14 +91,15 @@ func (r *region) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				},
 			},
 			"environment": schema.StringAttribute{
-				Description:         "The name of the environment the resource belongs to.",
-				MarkdownDescription: "The name of the environment the resource belongs to.",
-				Required:            true,
+				Description:         "The name of the environment the resource belongs to. Defaults to the provider's default_environment.",
+				MarkdownDescription: "The name of the environment the resource belongs to. Defaults to the provider's `default_environment`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					validators.EnvironmentValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"labels": schema.MapAttribute{
@@ -106,6 +120,18 @@ func (r *region) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"display_name": schema.StringAttribute{
 				Description:         "The user-friendly name of the region.",
 				MarkdownDescription: "The user-friendly name of the region.",
@@ -170,9 +196,17 @@ func (r *region) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *region) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *region) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -189,6 +223,11 @@ func (r *region) Configure(_ context.Context, req resource.ConfigureRequest, res
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.lists = procCtx.Lists
+	r.dryRun = procCtx.DryRun
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *region) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -198,7 +237,11 @@ func (r *region) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Create(ctx, plan.Timeouts)
+	defer cancel()
+
 	obj := plan.ToObject()
+	logging.Object(ctx, "Region", obj)
 	outObj, err := r.clientSet.CoreV1().Regions(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -207,10 +250,17 @@ func (r *region) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		)
 		return
 	}
+	r.lists.Invalidate(listcache.Key{Kind: "Region", Environment: obj.Environment})
 
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
 	plan = newRegionModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.Timeouts = cfgTimeouts
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
 }
 
 func (r *region) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -234,9 +284,15 @@ func (r *region) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 		return
 	}
 
+	labels, annotations, cfgTimeouts := state.Labels, state.Annotations, state.Timeouts
 	state = newRegionModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
+	state.Timeouts = cfgTimeouts
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.Name})...)
 }
 
 func (r *region) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -250,6 +306,9 @@ func (r *region) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Update(ctx, plan.Timeouts)
+	defer cancel()
+
 	oldObj := state.ToObject()
 	newObj := plan.ToObject()
 
@@ -262,16 +321,30 @@ func (r *region) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
-	if _, err = r.clientSet.CoreV1().Regions(newObj.Environment).Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Region", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.CoreV1().Regions(newObj.Environment), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Region", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Region",
 			fmt.Sprintf("Could not patch Region: %v", err),
 		)
 		return
 	}
+	r.lists.Invalidate(listcache.Key{Kind: "Region", Environment: newObj.Environment})
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	plan = newRegionModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.Timeouts = cfgTimeouts
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
 }
 
 func (r *region) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -281,6 +354,9 @@ func (r *region) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Delete(ctx, state.Timeouts)
+	defer cancel()
+
 	err := r.clientSet.CoreV1().Regions(state.Environment.ValueString()).Delete(ctx, state.Name.ValueString(), metav1.DeleteOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -289,6 +365,7 @@ func (r *region) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 		)
 		return
 	}
+	r.lists.Invalidate(listcache.Key{Kind: "Region", Environment: state.Environment.ValueString()})
 
 	if err = wait.PollUntilNotFound(ctx, r.clientSet.CoreV1().Regions(state.Environment.ValueString()), state.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
@@ -299,12 +376,27 @@ func (r *region) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 	}
 }
 
+// ModifyPlan fills in the provider's default environment when none is configured
+// and merges the provider's default labels and annotations.
+func (r *region) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("environment"), r.defaultEnvironment, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+	r.dryRunPlan(ctx, req, resp)
+}
+
 func (r *region) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if req.ID == "" {
-		return
-	}
+	identity.ImportNamespaced(ctx, req, resp, path.Root("name"), r.defaultEnvironment)
+}
 
-	env, name := cache.SplitMetaNamespaceKey(req.ID)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+// dryRunPlan validates the planned Region with a server-side dry run.
+func (r *region) dryRunPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	dryrun.ModifyPlan(ctx, r.dryRun, dryrun.Resource[*corev1.Region, regionModel]{
+		Kind: "Region",
+		Client: func(plan regionModel) dryrun.Client[*corev1.Region] {
+			return r.clientSet.CoreV1().Regions(plan.Environment.ValueString())
+		},
+		Name:     func(m regionModel) string { return m.Name.ValueString() },
+		ToObject: regionModel.ToObject,
+	}, req, resp)
 }
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewRegionList returns a new instance of the Region list resource.
+func NewRegionList() list.ListResource {
+	return listresource.NewNamespaced(listresource.Options[corev1.Region]{
+		TypeName: "region",
+		Plural:   "regions",
+		Kinds:    "Regions",
+		List: func(ctx context.Context, cs clientset.Interface, env string, opts metav1.ListOptions) ([]corev1.Region, error) {
+			objs, err := cs.CoreV1().Regions(env).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *corev1.Region, defaults listresource.Defaults) listresource.Result {
+			m := newRegionModel(obj)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Namespaced{Environment: m.Environment, Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
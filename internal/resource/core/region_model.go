@@ -8,32 +8,39 @@ import (
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type regionModel struct {
-	ID          types.String            `tfsdk:"id"`
-	Name        types.String            `tfsdk:"name"`
-	Environment types.String            `tfsdk:"environment"`
-	Labels      map[string]types.String `tfsdk:"labels"`
-	Annotations map[string]types.String `tfsdk:"annotations"`
-	DisplayName types.String            `tfsdk:"display_name"`
-	Description types.String            `tfsdk:"description"`
-	Allocator   types.String            `tfsdk:"allocator"`
-	Types       []regionTypeModel       `tfsdk:"types"`
+	ID             types.String            `tfsdk:"id"`
+	Name           types.String            `tfsdk:"name"`
+	Environment    types.String            `tfsdk:"environment"`
+	Labels         map[string]types.String `tfsdk:"labels"`
+	Annotations    map[string]types.String `tfsdk:"annotations"`
+	LabelsAll      map[string]types.String `tfsdk:"labels_all"`
+	AnnotationsAll map[string]types.String `tfsdk:"annotations_all"`
+	DisplayName    types.String            `tfsdk:"display_name"`
+	Description    types.String            `tfsdk:"description"`
+	Allocator      types.String            `tfsdk:"allocator"`
+	Types          []regionTypeModel       `tfsdk:"types"`
+	Timeouts       timeouts.Value          `tfsdk:"timeouts"`
 }
 
 func newRegionModel(obj *corev1.Region) regionModel {
 	model := regionModel{
-		ID:          types.StringValue(cache.NewObjectName(obj.Environment, obj.Name).String()),
-		Name:        types.StringValue(obj.Name),
-		Environment: types.StringValue(obj.Environment),
-		Labels:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
-		Annotations: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
-		DisplayName: types.StringValue(obj.Spec.DisplayName),
-		Description: conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
-		Allocator:   conv.OptionalFunc(obj.Spec.Allocator, types.StringValue, types.StringNull),
-		Types:       conv.ForEachSliceItem(obj.Spec.Types, newRegionTypeModel),
+		ID:             types.StringValue(cache.NewObjectName(obj.Environment, obj.Name).String()),
+		Name:           types.StringValue(obj.Name),
+		Environment:    types.StringValue(obj.Environment),
+		Labels:         conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		Annotations:    conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		LabelsAll:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		AnnotationsAll: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		DisplayName:    types.StringValue(obj.Spec.DisplayName),
+		Description:    conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
+		Allocator:      conv.OptionalFunc(obj.Spec.Allocator, types.StringValue, types.StringNull),
+		Types:          conv.ForEachSliceItem(obj.Spec.Types, newRegionTypeModel),
+		Timeouts:       timeouts.Null(),
 	}
 	return model
 }
@@ -43,8 +50,8 @@ func (m regionModel) ToObject() *corev1.Region {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
 			Environment: m.Environment.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: corev1.RegionSpec{
 			DisplayName: m.DisplayName.ValueString(),
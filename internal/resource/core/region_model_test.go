@@ -108,6 +108,12 @@ var (
 		Annotations: map[string]types.String{
 			"annotation-key": types.StringValue("annotation-value"),
 		},
+		LabelsAll: map[string]types.String{
+			"label-key": types.StringValue("label-value"),
+		},
+		AnnotationsAll: map[string]types.String{
+			"annotation-key": types.StringValue("annotation-value"),
+		},
 		DisplayName: types.StringValue("Test Region"),
 		Description: types.StringValue("Test Region Description"),
 		Allocator:   types.StringNull(),
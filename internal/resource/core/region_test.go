@@ -10,7 +10,10 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/provider/providertest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestRegion(t *testing.T) {
@@ -65,6 +68,38 @@ func TestRegion(t *testing.T) {
 	})
 }
 
+func TestRegion_Identity(t *testing.T) {
+	t.Parallel()
+
+	name := "eu"
+	pf, cs := providertest.ProtoV6ProviderFactories(t)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: pf,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		CheckDestroy: testResourceRegionDestroy(t, cs),
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceRegionConfigBasic(name),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("gamefabric_region.test", map[string]knownvalue.Check{
+						"environment": knownvalue.StringExact("dflt"),
+						"name":        knownvalue.StringExact(name),
+					}),
+				},
+			},
+			{
+				ResourceName:    "gamefabric_region.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 func TestRegion_Errored(t *testing.T) {
 	t.Parallel()
 
@@ -16,12 +16,18 @@ import (
 	secretreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/core/secret"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dryrun"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -34,8 +40,10 @@ import (
 const lastChangeSeenAnnotation = "tfp.g8c.io/secret-last-seen-data-change"
 
 var (
-	_ resource.Resource              = &secret{}
-	_ resource.ResourceWithConfigure = &secret{}
+	_ resource.Resource               = &secret{}
+	_ resource.ResourceWithConfigure  = &secret{}
+	_ resource.ResourceWithModifyPlan = &secret{}
+	_ resource.ResourceWithIdentity   = &secret{}
 )
 
 var secretValidator = validators.NewGameFabricValidator[*corev1.Secret, secretModel](func() validators.StoreValidator {
@@ -46,7 +54,11 @@ var secretValidator = validators.NewGameFabricValidator[*corev1.Secret, secretMo
 })
 
 type secret struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	dryRun             bool
+	defaultEnvironment types.String
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewSecret returns a new instance of the secret resource.
@@ -84,14 +96,15 @@ func (r *secret) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				},
 			},
 			"environment": schema.StringAttribute{
-				Description:         "The name of the environment the resource belongs to.",
-				MarkdownDescription: "The name of the environment the resource belongs to.",
-				Required:            true,
+				Description:         "The name of the environment the resource belongs to. Defaults to the provider's default_environment.",
+				MarkdownDescription: "The name of the environment the resource belongs to. Defaults to the provider's `default_environment`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					validators.EnvironmentValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"labels": schema.MapAttribute{
@@ -112,6 +125,18 @@ func (r *secret) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"description": schema.StringAttribute{
 				Description:         "Description is the optional description of the secret.",
 				MarkdownDescription: "Description is the optional description of the secret.",
@@ -152,6 +177,11 @@ func (r *secret) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *secret) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *secret) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -168,6 +198,10 @@ func (r *secret) Configure(_ context.Context, req resource.ConfigureRequest, res
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.dryRun = procCtx.DryRun
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *secret) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -186,6 +220,7 @@ func (r *secret) Create(ctx context.Context, req resource.CreateRequest, resp *r
 	plan.DataWO = config.DataWO
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "Secret", obj, "data")
 	outObj, err := r.clientSet.CoreV1().Secrets(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -205,7 +240,8 @@ func (r *secret) Create(ctx context.Context, req resource.CreateRequest, resp *r
 	}
 
 	// We know that we don't know the last data change timestamp.
-	if err = r.patchLastSeen(ctx, outObj, lastSeen); err != nil {
+	outObj, err = r.patchLastSeen(ctx, outObj, lastSeen)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Patching Secret Change Timestamp",
 			err.Error(),
@@ -213,7 +249,10 @@ func (r *secret) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
+	labels, annotations := plan.Labels, plan.Annotations
 	plan = newSecretModel(outObj, config.DataWOVersion.ValueInt64())
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
 	plan.Data = config.Data // Preserve plan's data as the API returns masked secrets.
 	if !config.DataWOVersion.IsNull() && config.DataWOVersion.ValueInt64() != 0 {
 		plan.Data = nil
@@ -221,6 +260,8 @@ func (r *secret) Create(ctx context.Context, req resource.CreateRequest, resp *r
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
 }
 
 func (r *secret) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -248,6 +289,9 @@ func (r *secret) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 		return
 	}
 
+	// Store the object before its last seen annotation is removed.
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+
 	lastChange, lastChangeSeen, err := r.acknowledgeLastSeen(outObj)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -263,7 +307,10 @@ func (r *secret) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 		outObj.Data = nil
 	}
 
+	labels, annotations := state.Labels, state.Annotations
 	state = newSecretModel(outObj, currentDataWOVersion.ValueInt64())
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
 
 	// Handle data based on whether using data_wo or regular data
 	switch {
@@ -286,6 +333,7 @@ func (r *secret) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.Name})...)
 }
 
 func (r *secret) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -324,8 +372,13 @@ func (r *secret) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
-	outObj, err := r.clientSet.CoreV1().Secrets(newObj.Environment).Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{})
+	logging.Patch(ctx, "Secret", pb, "data")
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.CoreV1().Secrets(newObj.Environment), newObj.Name, pb)
 	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Secret", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Secret",
 			fmt.Sprintf("Could not patch Secret: %v", err),
@@ -333,6 +386,8 @@ func (r *secret) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+
 	lastChange, lastChangeSeen, err := r.acknowledgeLastSeen(outObj)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -342,16 +397,20 @@ func (r *secret) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 	if !lastChange.Equal(lastChangeSeen) {
-		if err = r.patchLastSeen(ctx, outObj, lastChange); err != nil {
+		outObj, err = r.patchLastSeen(ctx, outObj, lastChange)
+		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Patching Secret Change Timestamp",
 				err.Error(),
 			)
 			return
 		}
+		resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 	}
 
 	updated := newSecretModel(outObj, plan.DataWOVersion.ValueInt64())
+	updated.Labels = conv.WithoutDefaults(updated.LabelsAll, plan.Labels, r.defaultLabels)
+	updated.Annotations = conv.WithoutDefaults(updated.AnnotationsAll, plan.Annotations, r.defaultAnnotations)
 
 	updated.Data = nil
 	if config.DataWOVersion.ValueInt64() == 0 {
@@ -360,6 +419,7 @@ func (r *secret) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 
 	resp.Diagnostics.Append(normalize.Model(ctx, &updated, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updated)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: updated.Environment, Name: updated.Name})...)
 }
 
 func (r *secret) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -387,6 +447,15 @@ func (r *secret) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 	}
 }
 
+// ModifyPlan fills in the provider's default environment when none is configured
+// and merges the provider's default labels and annotations.
+func (r *secret) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("environment"), r.defaultEnvironment, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+	r.dryRunPlan(ctx, req, resp)
+}
+
 func (r *secret) acknowledgeLastSeen(obj *corev1.Secret) (time.Time, time.Time, error) {
 	lastChange := obj.CreatedTimestamp
 	if obj.Status.LastDataChange != nil {
@@ -415,7 +484,7 @@ func (r *secret) acknowledgeLastSeen(obj *corev1.Secret) (time.Time, time.Time,
 	return lastChange, lastSeen, nil
 }
 
-func (r *secret) patchLastSeen(ctx context.Context, obj *corev1.Secret, lastChange time.Time) error {
+func (r *secret) patchLastSeen(ctx context.Context, obj *corev1.Secret, lastChange time.Time) (*corev1.Secret, error) {
 	patchObj := runtime.DeepCopy(obj)
 	if patchObj.Annotations == nil {
 		patchObj.Annotations = map[string]string{}
@@ -424,15 +493,16 @@ func (r *secret) patchLastSeen(ctx context.Context, obj *corev1.Secret, lastChan
 
 	pb, err := patch.Create(obj, patchObj)
 	if err != nil {
-		return fmt.Errorf("creating patch: %w", err)
+		return nil, fmt.Errorf("creating patch: %w", err)
 	}
 
-	_, err = r.clientSet.CoreV1().Secrets(patchObj.Environment).Patch(ctx, patchObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{})
+	logging.Patch(ctx, "Secret", pb, "data")
+	outObj, err := r.clientSet.CoreV1().Secrets(patchObj.Environment).Patch(ctx, patchObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{})
 	if err != nil {
-		return fmt.Errorf("patching: %w", err)
+		return nil, fmt.Errorf("patching: %w", err)
 	}
 
-	return nil
+	return outObj, nil
 }
 
 func sameKeys(mp1 map[string]types.String, mp2 map[string]string) bool {
@@ -446,3 +516,22 @@ func sameKeys(mp1 map[string]types.String, mp2 map[string]string) bool {
 	}
 	return true
 }
+
+// dryRunPlan validates the planned Secret with a server-side dry run.
+func (r *secret) dryRunPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	dryrun.ModifyPlan(ctx, r.dryRun, dryrun.Resource[*corev1.Secret, secretModel]{
+		Kind: "Secret",
+		Client: func(plan secretModel) dryrun.Client[*corev1.Secret] {
+			return r.clientSet.CoreV1().Secrets(plan.Environment.ValueString())
+		},
+		Name:     func(m secretModel) string { return m.Name.ValueString() },
+		ToObject: secretModel.ToObject,
+		Prepare: func(ctx context.Context, req resource.ModifyPlanRequest, plan, _ *secretModel) diag.Diagnostics {
+			// Write-only data is only part of the config.
+			var config secretModel
+			diags := req.Config.Get(ctx, &config)
+			plan.DataWO = config.DataWO
+			return diags
+		},
+	}, req, resp)
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewSecretList returns a new instance of the Secret list resource.
+func NewSecretList() list.ListResource {
+	return listresource.NewNamespaced(listresource.Options[corev1.Secret]{
+		TypeName: "secret",
+		Plural:   "secrets",
+		Kinds:    "Secrets",
+		Note:     "Secret data is not returned, as the GameFabric API only returns masked values. Listed secrets cannot be imported.",
+		List: func(ctx context.Context, cs clientset.Interface, env string, opts metav1.ListOptions) ([]corev1.Secret, error) {
+			objs, err := cs.CoreV1().Secrets(env).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *corev1.Secret, defaults listresource.Defaults) listresource.Result {
+			m := newSecretModel(obj, 0)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			m.Data = nil // The API returns masked secret data.
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Namespaced{Environment: m.Environment, Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
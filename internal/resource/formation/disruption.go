@@ -0,0 +1,138 @@
+package formation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	formationv1 "github.com/gamefabric/gf-core/pkg/api/formation/v1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// formationChange describes how an update affects the running vessels of a Formation.
+type formationChange struct {
+	// Attributes are the changed attributes that cause the disruption.
+	Attributes []string
+	// Restarted are the vessels whose game servers are restarted with a new template.
+	Restarted []string
+	// Removed are the vessels whose game servers are shut down, because the vessels
+	// are removed, moved to another region or the Formation is replaced.
+	Removed []string
+	// Unknown are the attributes that are only known after apply.
+	Unknown []string
+	// Possible are the vessels whose game servers may be restarted or shut down,
+	// depending on the values of the unknown attributes.
+	Possible []string
+}
+
+// disruptiveAttributes are the attributes whose changes can restart or shut down
+// running vessels.
+var disruptiveAttributes = []string{
+	"vessels",
+	"containers",
+	"health_checks",
+	"termination_configuration",
+	"volumes",
+	"gateway_policies",
+	"gameserver_labels",
+	"gameserver_annotations",
+	"profiling_enabled",
+}
+
+// classifyFormationChange classifies the update from oldObj to newObj.
+// replace holds the attributes that force the Formation to be replaced and unknown
+// the attributes that are only known after apply. The values of unknown attributes
+// in newObj are not compared.
+// Suspended vessels have no running game server and are not affected.
+func classifyFormationChange(oldObj, newObj *formationv1.Formation, replace, unknown []string) formationChange {
+	change := formationChange{Unknown: unknown}
+	if len(replace) > 0 {
+		change.Attributes = replace
+	} else {
+		change.Attributes = templateChanges(oldObj.Spec.Template, newObj.Spec.Template, unknown)
+	}
+	templateChanged := len(change.Attributes) > 0
+	vesselsUnknown := slices.Contains(unknown, "vessels")
+
+	vesselsChanged := false
+	for _, oldVessel := range oldObj.Spec.Vessels {
+		if oldVessel.Suspend != nil && *oldVessel.Suspend {
+			continue
+		}
+
+		idx := slices.IndexFunc(newObj.Spec.Vessels, func(v formationv1.VesselTemplate) bool { return v.Name == oldVessel.Name })
+		switch {
+		case len(replace) > 0:
+			change.Removed = append(change.Removed, oldVessel.Name)
+		case vesselsUnknown && templateChanged:
+			change.Restarted = append(change.Restarted, oldVessel.Name)
+		case vesselsUnknown:
+			change.Possible = append(change.Possible, oldVessel.Name)
+		case idx < 0, newObj.Spec.Vessels[idx].Region != oldVessel.Region:
+			vesselsChanged = true
+			change.Removed = append(change.Removed, oldVessel.Name)
+		case !equality.Semantic.DeepEqual(oldVessel.Override, newObj.Spec.Vessels[idx].Override):
+			vesselsChanged = true
+			change.Restarted = append(change.Restarted, oldVessel.Name)
+		case templateChanged:
+			change.Restarted = append(change.Restarted, oldVessel.Name)
+		case len(unknown) > 0:
+			change.Possible = append(change.Possible, oldVessel.Name)
+		}
+	}
+	if vesselsChanged {
+		change.Attributes = append(change.Attributes, "vessels")
+	}
+	return change
+}
+
+// templateChanges returns the attributes of the game server template that differ
+// between o and n, skipping the unknown ones. Changing any of them
+// restarts the game servers of all vessels.
+func templateChanges(o, n formationv1.GameServerTemplateSpec, unknown []string) []string {
+	var attrs []string
+	changed := func(attr string, a, b any) {
+		if !slices.Contains(unknown, attr) && !equality.Semantic.DeepEqual(a, b) {
+			attrs = append(attrs, attr)
+		}
+	}
+	changed("containers", o.Spec.Containers, n.Spec.Containers)
+	changed("health_checks", o.Spec.Health, n.Spec.Health)
+	changed("termination_configuration", o.Spec.TerminationGracePeriodSeconds, n.Spec.TerminationGracePeriodSeconds)
+	changed("volumes", o.Spec.Volumes, n.Spec.Volumes)
+	changed("gateway_policies", o.Spec.GatewayPolicies, n.Spec.GatewayPolicies)
+	changed("gameserver_labels", conv.MapWithoutKey(o.Labels, profilingKey), conv.MapWithoutKey(n.Labels, profilingKey))
+	changed("gameserver_annotations", o.Annotations, n.Annotations)
+	changed("profiling_enabled", o.Labels[profilingKey], n.Labels[profilingKey])
+	return attrs
+}
+
+// diagnostics returns a warning for each kind of disruption in c.
+func (c formationChange) diagnostics() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attrs := strings.Join(c.Attributes, ", ")
+	if len(c.Removed) > 0 {
+		diags.AddWarning(
+			"Destructive Formation Update",
+			fmt.Sprintf("Changing %s shuts down the game servers of vessels %s, including allocated ones.",
+				attrs, strings.Join(c.Removed, ", ")),
+		)
+	}
+	if len(c.Restarted) > 0 {
+		diags.AddWarning(
+			"Rolling Formation Update",
+			fmt.Sprintf("Changing %s restarts the game servers of vessels %s.", attrs, strings.Join(c.Restarted, ", ")),
+		)
+	}
+	if len(c.Possible) > 0 {
+		diags.AddWarning(
+			"Possible Rolling Formation Update",
+			fmt.Sprintf("%s are only known after apply. If they change, the game servers of vessels %s may be "+
+				"restarted or shut down.", strings.Join(c.Unknown, ", "), strings.Join(c.Possible, ", ")),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,142 @@
+package formation
+
+import (
+	"testing"
+
+	formationv1 "github.com/gamefabric/gf-core/pkg/api/formation/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyFormationChange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		update  func(obj *formationv1.Formation)
+		replace []string
+		unknown []string
+		want    formationChange
+	}{
+		{
+			name: "in place",
+			update: func(obj *formationv1.Formation) {
+				obj.Spec.Description = "new"
+				obj.Spec.Vessels[0].Description = "new"
+			},
+			want: formationChange{},
+		},
+		{
+			name: "template",
+			update: func(obj *formationv1.Formation) {
+				obj.Spec.Template.Spec.Containers[0].Image = "game:2"
+			},
+			want: formationChange{
+				Attributes: []string{"containers"},
+				Restarted:  []string{"vessel-1", "vessel-2"},
+			},
+		},
+		{
+			name: "vessel override",
+			update: func(obj *formationv1.Formation) {
+				obj.Spec.Vessels[1].Override.Labels = map[string]string{"team": "a"}
+			},
+			want: formationChange{
+				Attributes: []string{"vessels"},
+				Restarted:  []string{"vessel-2"},
+			},
+		},
+		{
+			name: "vessel removed and moved",
+			update: func(obj *formationv1.Formation) {
+				obj.Spec.Vessels = obj.Spec.Vessels[1:]
+				obj.Spec.Vessels[0].Region = "us"
+			},
+			want: formationChange{
+				Attributes: []string{"vessels"},
+				Removed:    []string{"vessel-1", "vessel-2"},
+			},
+		},
+		{
+			name: "unknown template",
+			update: func(obj *formationv1.Formation) {
+				obj.Spec.Template.Spec.Containers = nil
+			},
+			unknown: []string{"containers"},
+			want: formationChange{
+				Unknown:  []string{"containers"},
+				Possible: []string{"vessel-1", "vessel-2"},
+			},
+		},
+		{
+			name: "unknown vessels",
+			update: func(obj *formationv1.Formation) {
+				obj.Spec.Vessels = nil
+			},
+			unknown: []string{"vessels"},
+			want: formationChange{
+				Unknown:  []string{"vessels"},
+				Possible: []string{"vessel-1", "vessel-2"},
+			},
+		},
+		{
+			name: "replace",
+			update: func(obj *formationv1.Formation) {
+				obj.Name = "other"
+			},
+			replace: []string{"name"},
+			want: formationChange{
+				Attributes: []string{"name"},
+				Removed:    []string{"vessel-1", "vessel-2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			oldObj, newObj := testFormation(), testFormation()
+			test.update(newObj)
+
+			got := classifyFormationChange(oldObj, newObj, test.replace, test.unknown)
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFormationChange_Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	change := formationChange{
+		Attributes: []string{"containers"},
+		Restarted:  []string{"vessel-1"},
+		Unknown:    []string{"volumes"},
+		Possible:   []string{"vessel-2"},
+	}
+
+	got := change.diagnostics()
+
+	assert.Equal(t, 2, got.WarningsCount())
+	assert.Equal(t, "Rolling Formation Update", got[0].Summary())
+	assert.Equal(t, "Possible Rolling Formation Update", got[1].Summary())
+	assert.Contains(t, got[1].Detail(), "volumes are only known after apply.")
+	assert.Contains(t, got[1].Detail(), "vessel-2")
+}
+
+func testFormation() *formationv1.Formation {
+	return &formationv1.Formation{
+		Spec: formationv1.FormationSpec{
+			Vessels: []formationv1.VesselTemplate{
+				{Name: "vessel-1", Region: "eu"},
+				{Name: "vessel-2", Region: "eu"},
+				{Name: "vessel-3", Region: "eu", Suspend: new(true)},
+			},
+			Template: formationv1.GameServerTemplateSpec{
+				Spec: formationv1.GameServerSpec{
+					Containers: []formationv1.Container{{Name: "game", Image: "game:1"}},
+				},
+			},
+		},
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
-	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,17 +12,27 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	formationreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/formation/formation"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/deletionprotection"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dryrun"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/core"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/tfutils"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -40,6 +48,8 @@ var (
 	_ resource.Resource                = &formation{}
 	_ resource.ResourceWithConfigure   = &formation{}
 	_ resource.ResourceWithImportState = &formation{}
+	_ resource.ResourceWithModifyPlan  = &formation{}
+	_ resource.ResourceWithIdentity    = &formation{}
 )
 
 var formationValidator = validators.NewGameFabricValidator[*formationv1.Formation, formationModel](func() validators.StoreValidator {
@@ -50,7 +60,13 @@ var formationValidator = validators.NewGameFabricValidator[*formationv1.Formatio
 })
 
 type formation struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	configUnknown      bool
+	dryRun             bool
+	validateReferences bool
+	defaultEnvironment types.String
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewFormation returns a new instance of the Formation resource.
@@ -64,7 +80,7 @@ func (r *formation) Metadata(_ context.Context, req resource.MetadataRequest, re
 }
 
 // Schema defines the schema for this data source.
-func (r *formation) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) { //nolint:maintidx // Keep schema in one place.
+func (r *formation) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) { //nolint:maintidx // Keep schema in one place.
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -87,14 +103,15 @@ func (r *formation) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 				},
 			},
 			"environment": schema.StringAttribute{
-				Description:         "The name of the environment the object belongs to.",
-				MarkdownDescription: "The name of the environment the object belongs to.",
-				Required:            true,
+				Description:         "The name of the environment the object belongs to. Defaults to the provider's default_environment.",
+				MarkdownDescription: "The name of the environment the object belongs to. Defaults to the provider's `default_environment`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					validators.EnvironmentValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"description": schema.StringAttribute{
@@ -120,6 +137,18 @@ func (r *formation) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"volume_templates": schema.ListNestedAttribute{
 				Description:         "VolumeTemplates are the templates for volumes that can be mounted by containers belonging to the game server.",
 				MarkdownDescription: "VolumeTemplates are the templates for volumes that can be mounted by containers belonging to the game server.",
@@ -446,6 +475,11 @@ func (r *formation) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 				MarkdownDescription: "ProfilingEnabled indicates whether profiling is enabled for the Formation.",
 				Optional:            true,
 			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description:         "Wait on create and update until the Formation is ready: its latest change was acted on and the game servers of all its vessels are ready, or suspended if 'suspend' is set. The wait is bounded by the 'timeouts' block. Defaults to 'false'.",
+				MarkdownDescription: "Wait on create and update until the Formation is ready: its latest change was acted on and the game servers of all its vessels are ready, or suspended if `suspend` is set. The wait is bounded by the `timeouts` block. Defaults to `false`.",
+				Optional:            true,
+			},
 			"image_updater_target": schema.SingleNestedAttribute{
 				Description:         "ImageUpdaterTarget is the reference that an image updater can target to match the Formation.",
 				MarkdownDescription: "ImageUpdaterTarget is the reference that an image updater can target to match the Formation.",
@@ -471,10 +505,19 @@ func (r *formation) Schema(_ context.Context, _ resource.SchemaRequest, resp *re
 					},
 				},
 			},
+			"deletion_protection": deletionprotection.Attribute("Formation"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *formation) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *formation) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -491,6 +534,12 @@ func (r *formation) Configure(_ context.Context, req resource.ConfigureRequest,
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.configUnknown = procCtx.ConfigUnknown
+	r.dryRun = procCtx.DryRun
+	r.validateReferences = procCtx.ValidateReferences
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *formation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -500,7 +549,11 @@ func (r *formation) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	ctx, cancel := timeouts.Create(ctx, plan.Timeouts)
+	defer cancel()
+
 	obj := plan.ToObject()
+	logging.Object(ctx, "Formation", obj)
 	outObj, err := r.clientSet.FormationV1().Formations(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -510,9 +563,22 @@ func (r *formation) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForReady := plan.WaitForReady
+	deletionProtection := plan.DeletionProtection
 	plan = newFormationModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForReady = waitForReady
+	plan.Timeouts = cfgTimeouts
+	plan.DeletionProtection = deletionProtection
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForReady.ValueBool() {
+		resp.Diagnostics.Append(r.waitForReady(ctx, plan)...)
+	}
 }
 
 func (r *formation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -536,9 +602,19 @@ func (r *formation) Read(ctx context.Context, req resource.ReadRequest, resp *re
 		return
 	}
 
+	labels, annotations, cfgTimeouts := state.Labels, state.Annotations, state.Timeouts
+	waitForReady := state.WaitForReady
+	deletionProtection := state.DeletionProtection
 	state = newFormationModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
+	state.WaitForReady = waitForReady
+	state.Timeouts = cfgTimeouts
+	state.DeletionProtection = deletionProtection
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.Name})...)
 }
 
 func (r *formation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -553,6 +629,9 @@ func (r *formation) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	ctx, cancel := timeouts.Update(ctx, plan.Timeouts)
+	defer cancel()
+
 	oldObj := state.ToObject()
 	newObj := plan.ToObject()
 
@@ -565,26 +644,49 @@ func (r *formation) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	if _, err = r.clientSet.FormationV1().Formations(newObj.Environment).Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Formation", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.FormationV1().Formations(newObj.Environment), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Formation", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Formation",
 			fmt.Sprintf("Could not patch Formation: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
-	plan.ImageUpdaterTarget = container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeFormation, oldObj.Name, oldObj.Environment)
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForReady := plan.WaitForReady
+	deletionProtection := plan.DeletionProtection
+	plan = newFormationModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForReady = waitForReady
+	plan.Timeouts = cfgTimeouts
+	plan.DeletionProtection = deletionProtection
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForReady.ValueBool() {
+		resp.Diagnostics.Append(r.waitForReady(ctx, plan)...)
+	}
 }
 
 func (r *formation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state formationModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(deletionprotection.Check("Formation", state.Name.ValueString(), state.DeletionProtection)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := timeouts.Delete(ctx, state.Timeouts)
+	defer cancel()
+
 	err := r.clientSet.FormationV1().Formations(state.Environment.ValueString()).Delete(ctx, state.Name.ValueString(), metav1.DeleteOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -603,12 +705,88 @@ func (r *formation) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	}
 }
 
+// ModifyPlan fills in the provider's default environment when none is configured
+// and merges the provider's default labels and annotations. It warns about updates
+// that restart or shut down running vessels.
+func (r *formation) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("environment"), r.defaultEnvironment, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+	r.warnDisruption(ctx, req, resp)
+	r.checkReferences(ctx, req, resp)
+	r.dryRunPlan(ctx, req, resp)
+}
+
 func (r *formation) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if req.ID == "" {
+	identity.ImportNamespaced(ctx, req, resp, path.Root("name"), r.defaultEnvironment)
+}
+
+// waitForReady waits until the Formation is ready.
+func (r *formation) waitForReady(ctx context.Context, m formationModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err := wait.PollUntil(ctx, r.clientSet.FormationV1().Formations(m.Environment.ValueString()), m.Name.ValueString(), wait.Ready(formationReadyStatus), wait.Awaiting("readiness")); err != nil {
+		diags.AddError(
+			"Error Waiting for Formation Readiness",
+			fmt.Sprintf("Could not wait for readiness of Formation: %v", err),
+		)
+	}
+	return diags
+}
+
+// warnDisruption warns if the planned update restarts or shuts down running vessels.
+func (r *formation) warnDisruption(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		// Nothing is running yet.
+		return
+	}
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan formationModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state formationModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	replace := make([]string, 0, len(resp.RequiresReplace))
+	for _, p := range resp.RequiresReplace {
+		replace = append(replace, p.String())
+	}
+	unknown := tfutils.UnknownAttributes(resp.Plan, disruptiveAttributes...)
+	change := classifyFormationChange(state.ToObject(), plan.ToObject(), replace, unknown)
+	resp.Diagnostics.Append(change.diagnostics()...)
+}
+
+// checkReferences reports references of the planned Formation to config files, secrets
+// and gateway policies that do not exist.
+func (r *formation) checkReferences(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The API cannot be reached while the provider configuration is unknown.
+	if !r.validateReferences || r.configUnknown || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
 		return
 	}
 
-	env, name := cache.SplitMetaNamespaceKey(req.ID)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+	var plan formationModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(mps.ValidateReferences(ctx, r.clientSet, plan.Environment, plan.Containers, plan.GatewayPolicies)...)
+}
+
+// dryRunPlan validates the planned Formation with a server-side dry run.
+func (r *formation) dryRunPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	dryrun.ModifyPlan(ctx, r.dryRun, dryrun.Resource[*formationv1.Formation, formationModel]{
+		Kind: "Formation",
+		Client: func(plan formationModel) dryrun.Client[*formationv1.Formation] {
+			return r.clientSet.FormationV1().Formations(plan.Environment.ValueString())
+		},
+		Name:     func(m formationModel) string { return m.Name.ValueString() },
+		ToObject: formationModel.ToObject,
+	}, req, resp)
 }
@@ -0,0 +1,39 @@
+package formation
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	formationv1 "github.com/gamefabric/gf-core/pkg/api/formation/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewFormationList returns a new instance of the Formation list resource.
+func NewFormationList() list.ListResource {
+	return listresource.NewNamespaced(listresource.Options[formationv1.Formation]{
+		TypeName: "formation",
+		Plural:   "formations",
+		Kinds:    "Formations",
+		List: func(ctx context.Context, cs clientset.Interface, env string, opts metav1.ListOptions) ([]formationv1.Formation, error) {
+			objs, err := cs.FormationV1().Formations(env).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *formationv1.Formation, defaults listresource.Defaults) listresource.Result {
+			m := newFormationModel(obj)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Namespaced{Environment: m.Environment, Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
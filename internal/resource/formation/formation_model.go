@@ -12,6 +12,7 @@ import (
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/core"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,6 +23,8 @@ type formationModel struct {
 	Description           types.String                       `tfsdk:"description"`
 	Labels                map[string]types.String            `tfsdk:"labels"`
 	Annotations           map[string]types.String            `tfsdk:"annotations"`
+	LabelsAll             map[string]types.String            `tfsdk:"labels_all"`
+	AnnotationsAll        map[string]types.String            `tfsdk:"annotations_all"`
 	VolumeTemplates       []VolumeTemplateModel              `tfsdk:"volume_templates"`
 	Vessels               []VesselTemplateModel              `tfsdk:"vessels"`
 	GameServerLabels      map[string]types.String            `tfsdk:"gameserver_labels"`
@@ -33,6 +36,9 @@ type formationModel struct {
 	GatewayPolicies       []types.String                     `tfsdk:"gateway_policies"`
 	ProfilingEnabled      types.Bool                         `tfsdk:"profiling_enabled"`
 	ImageUpdaterTarget    *container.ImageUpdaterTargetModel `tfsdk:"image_updater_target"`
+	WaitForReady          types.Bool                         `tfsdk:"wait_for_ready"`
+	DeletionProtection    types.Bool                         `tfsdk:"deletion_protection"`
+	Timeouts              timeouts.Value                     `tfsdk:"timeouts"`
 }
 
 func newFormationModel(obj *formationv1.Formation) formationModel {
@@ -43,6 +49,8 @@ func newFormationModel(obj *formationv1.Formation) formationModel {
 		Description:           conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
 		Labels:                conv.ForEachMapItem(obj.Labels, types.StringValue),
 		Annotations:           conv.ForEachMapItem(obj.Annotations, types.StringValue),
+		LabelsAll:             conv.ForEachMapItem(obj.Labels, types.StringValue),
+		AnnotationsAll:        conv.ForEachMapItem(obj.Annotations, types.StringValue),
 		VolumeTemplates:       conv.ForEachSliceItem(obj.Spec.VolumeTemplates, newVolumeTemplate),
 		Vessels:               conv.ForEachSliceItem(obj.Spec.Vessels, newVesselTemplateModel),
 		GameServerLabels:      conv.ForEachMapItem(conv.MapWithoutKey(obj.Spec.Template.Labels, profilingKey), types.StringValue),
@@ -54,6 +62,7 @@ func newFormationModel(obj *formationv1.Formation) formationModel {
 		GatewayPolicies:       conv.ForEachSliceItem(obj.Spec.Template.Spec.GatewayPolicies, types.StringValue),
 		ProfilingEnabled:      conv.BoolFromMapKey(obj.Spec.Template.Labels, profilingKey, types.BoolValue(false)),
 		ImageUpdaterTarget:    container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeFormation, obj.Name, obj.Environment),
+		Timeouts:              timeouts.Null(),
 	}
 }
 
@@ -62,8 +71,8 @@ func (m formationModel) ToObject() *formationv1.Formation {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
 			Environment: m.Environment.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(v types.String) string { return v.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(v types.String) string { return v.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(v types.String) string { return v.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(v types.String) string { return v.ValueString() }),
 		},
 		Spec: formationv1.FormationSpec{
 			Description: m.Description.ValueString(),
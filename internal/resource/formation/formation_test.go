@@ -200,9 +200,9 @@ func TestResourceFormation_Validates(t *testing.T) {
 			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "name" is required`)),
 		},
 		{
-			name:        "requires environment",
-			config:      testResourceFormationConfigEmpty(),
-			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "environment" is required`)),
+			name:        "requires environment without default environment",
+			config:      strings.Replace(testResourceFormationConfigBasic(), `environment = "test"`, "", 1),
+			expectError: regexp.MustCompile(regexp.QuoteMeta(`Missing Environment`)),
 		},
 		{
 			name:        "requires containers",
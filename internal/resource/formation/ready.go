@@ -0,0 +1,52 @@
+package formation
+
+import (
+	formationv1 "github.com/gamefabric/gf-core/pkg/api/formation/v1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
+)
+
+// vesselReadyStatus returns the readiness state of a Vessel.
+func vesselReadyStatus(obj *formationv1.Vessel) wait.ReadyStatus {
+	return wait.ReadyStatus{
+		Generation:         obj.Generation,
+		ObservedGeneration: obj.Status.ObservedGeneration,
+		Ready:              vesselReady(obj.Spec.Suspend, string(obj.Status.State)),
+	}
+}
+
+// formationReadyStatus returns the readiness state of a Formation.
+// It is ready once all of its vessels are ready.
+func formationReadyStatus(obj *formationv1.Formation) wait.ReadyStatus {
+	states := make(map[string]string, len(obj.Status.Vessels))
+	for _, st := range obj.Status.Vessels {
+		states[st.Name] = string(st.State)
+	}
+
+	ready := true
+	for _, v := range obj.Spec.Vessels {
+		state, ok := states[v.Name]
+		if !ok || !vesselReady(v.Suspend, state) {
+			ready = false
+			break
+		}
+	}
+	return wait.ReadyStatus{
+		Generation:         obj.Generation,
+		ObservedGeneration: obj.Status.ObservedGeneration,
+		Ready:              ready,
+	}
+}
+
+// vesselReady reports whether a vessel in the given state reached its desired state:
+// suspended if suspend is set, otherwise running a game server that is ready or in use.
+func vesselReady(suspend *bool, state string) bool {
+	if suspend != nil && *suspend {
+		return state == "Suspended"
+	}
+	switch state {
+	case "Ready", "Reserved", "Allocated":
+		return true
+	default:
+		return false
+	}
+}
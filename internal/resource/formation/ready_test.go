@@ -0,0 +1,58 @@
+package formation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVesselReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		suspend *bool
+		state   string
+		want    bool
+	}{
+		{
+			name:  "ready",
+			state: "Ready",
+			want:  true,
+		},
+		{
+			name:  "allocated",
+			state: "Allocated",
+			want:  true,
+		},
+		{
+			name:  "starting",
+			state: "Scheduled",
+		},
+		{
+			name:    "suspended",
+			suspend: new(true),
+			state:   "Suspended",
+			want:    true,
+		},
+		{
+			name:    "still running while suspending",
+			suspend: new(true),
+			state:   "Ready",
+		},
+		{
+			name:    "not suspended",
+			suspend: new(false),
+			state:   "Ready",
+			want:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, vesselReady(test.suspend, test.state))
+		})
+	}
+}
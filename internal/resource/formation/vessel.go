@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
-	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,15 +12,23 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	vesselreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/formation/vessel"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/dryrun"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
-	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/tfutils"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -38,6 +44,8 @@ var (
 	_ resource.Resource                = &vessel{}
 	_ resource.ResourceWithConfigure   = &vessel{}
 	_ resource.ResourceWithImportState = &vessel{}
+	_ resource.ResourceWithModifyPlan  = &vessel{}
+	_ resource.ResourceWithIdentity    = &vessel{}
 )
 
 var vesselValidator = validators.NewGameFabricValidator[*formationv1.Vessel, vesselModel](func() validators.StoreValidator {
@@ -48,7 +56,13 @@ var vesselValidator = validators.NewGameFabricValidator[*formationv1.Vessel, ves
 })
 
 type vessel struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	configUnknown      bool
+	dryRun             bool
+	validateReferences bool
+	defaultEnvironment types.String
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewVessel returns a new instance of the Vessel resource.
@@ -62,7 +76,7 @@ func (r *vessel) Metadata(_ context.Context, req resource.MetadataRequest, resp
 }
 
 // Schema defines the schema for this data source.
-func (r *vessel) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *vessel) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -87,14 +101,15 @@ func (r *vessel) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				},
 			},
 			"environment": schema.StringAttribute{
-				Description:         "The name of the environment the object belongs to.",
-				MarkdownDescription: "The name of the environment the object belongs to.",
-				Required:            true,
+				Description:         "The name of the environment the object belongs to. Defaults to the provider's default_environment.",
+				MarkdownDescription: "The name of the environment the object belongs to. Defaults to the provider's `default_environment`.",
+				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					validators.EnvironmentValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"description": schema.StringAttribute{
@@ -129,6 +144,18 @@ func (r *vessel) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"region": schema.StringAttribute{
 				Description:         "Region defines the region the game servers are distributed to.",
 				MarkdownDescription: "Region defines the region the game servers are distributed to.",
@@ -292,6 +319,11 @@ func (r *vessel) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				MarkdownDescription: "ProfilingEnabled indicates whether profiling is enabled for the Vessel.",
 				Optional:            true,
 			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description:         "Wait on create and update until the Vessel is ready: its latest change was acted on and its game server is ready, or suspended if 'suspend' is set. The wait is bounded by the 'timeouts' block. Defaults to 'false'.",
+				MarkdownDescription: "Wait on create and update until the Vessel is ready: its latest change was acted on and its game server is ready, or suspended if `suspend` is set. The wait is bounded by the `timeouts` block. Defaults to `false`.",
+				Optional:            true,
+			},
 			"image_updater_target": schema.SingleNestedAttribute{
 				Description:         "ImageUpdaterTarget is the reference that an image updater can target to match the Vessel.",
 				MarkdownDescription: "ImageUpdaterTarget is the reference that an image updater can target to match the Vessel.",
@@ -318,9 +350,17 @@ func (r *vessel) Schema(_ context.Context, _ resource.SchemaRequest, resp *resou
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *vessel) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *vessel) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -337,6 +377,12 @@ func (r *vessel) Configure(_ context.Context, req resource.ConfigureRequest, res
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.configUnknown = procCtx.ConfigUnknown
+	r.dryRun = procCtx.DryRun
+	r.validateReferences = procCtx.ValidateReferences
+	r.defaultEnvironment = procCtx.DefaultEnvironment
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *vessel) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -346,7 +392,11 @@ func (r *vessel) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Create(ctx, plan.Timeouts)
+	defer cancel()
+
 	obj := plan.ToObject()
+	logging.Object(ctx, "Vessel", obj)
 	outObj, err := r.clientSet.FormationV1().Vessels(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -356,9 +406,20 @@ func (r *vessel) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForReady := plan.WaitForReady
 	plan = newVesselModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForReady = waitForReady
+	plan.Timeouts = cfgTimeouts
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForReady.ValueBool() {
+		resp.Diagnostics.Append(r.waitForReady(ctx, plan)...)
+	}
 }
 
 func (r *vessel) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -382,9 +443,17 @@ func (r *vessel) Read(ctx context.Context, req resource.ReadRequest, resp *resou
 		return
 	}
 
+	labels, annotations, cfgTimeouts := state.Labels, state.Annotations, state.Timeouts
+	waitForReady := state.WaitForReady
 	state = newVesselModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
+	state.WaitForReady = waitForReady
+	state.Timeouts = cfgTimeouts
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.Name})...)
 }
 
 func (r *vessel) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -399,6 +468,9 @@ func (r *vessel) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Update(ctx, plan.Timeouts)
+	defer cancel()
+
 	oldObj := state.ToObject()
 	newObj := plan.ToObject()
 
@@ -411,17 +483,34 @@ func (r *vessel) Update(ctx context.Context, req resource.UpdateRequest, resp *r
 		return
 	}
 
-	if _, err = r.clientSet.FormationV1().Vessels(newObj.Environment).Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Vessel", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.FormationV1().Vessels(newObj.Environment), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Vessel", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Vessel",
 			fmt.Sprintf("Could not patch Vessel: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
-	plan.ImageUpdaterTarget = container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeVessel, oldObj.Name, oldObj.Environment)
+	labels, annotations, cfgTimeouts := plan.Labels, plan.Annotations, plan.Timeouts
+	waitForReady := plan.WaitForReady
+	plan = newVesselModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	plan.WaitForReady = waitForReady
+	plan.Timeouts = cfgTimeouts
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.Name})...)
+	if plan.WaitForReady.ValueBool() {
+		resp.Diagnostics.Append(r.waitForReady(ctx, plan)...)
+	}
 }
 
 func (r *vessel) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -431,6 +520,9 @@ func (r *vessel) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 		return
 	}
 
+	ctx, cancel := timeouts.Delete(ctx, state.Timeouts)
+	defer cancel()
+
 	err := r.clientSet.FormationV1().Vessels(state.Environment.ValueString()).Delete(ctx, state.Name.ValueString(), metav1.DeleteOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -449,12 +541,56 @@ func (r *vessel) Delete(ctx context.Context, req resource.DeleteRequest, resp *r
 	}
 }
 
+// ModifyPlan fills in the provider's default environment when none is configured
+// and merges the provider's default labels and annotations.
+func (r *vessel) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.DefaultEnvironment(ctx, path.Root("environment"), r.defaultEnvironment, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+	r.checkReferences(ctx, req, resp)
+	r.dryRunPlan(ctx, req, resp)
+}
+
 func (r *vessel) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if req.ID == "" {
+	identity.ImportNamespaced(ctx, req, resp, path.Root("name"), r.defaultEnvironment)
+}
+
+// waitForReady waits until the Vessel is ready.
+func (r *vessel) waitForReady(ctx context.Context, m vesselModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err := wait.PollUntil(ctx, r.clientSet.FormationV1().Vessels(m.Environment.ValueString()), m.Name.ValueString(), wait.Ready(vesselReadyStatus), wait.Awaiting("readiness")); err != nil {
+		diags.AddError(
+			"Error Waiting for Vessel Readiness",
+			fmt.Sprintf("Could not wait for readiness of Vessel: %v", err),
+		)
+	}
+	return diags
+}
+
+// checkReferences reports references of the planned Vessel to config files, secrets
+// and gateway policies that do not exist.
+func (r *vessel) checkReferences(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The API cannot be reached while the provider configuration is unknown.
+	if !r.validateReferences || r.configUnknown || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
 		return
 	}
 
-	env, name := cache.SplitMetaNamespaceKey(req.ID)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+	var plan vesselModel
+	resp.Diagnostics.Append(tfutils.GetKnown(ctx, resp.Plan, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(mps.ValidateReferences(ctx, r.clientSet, plan.Environment, plan.Containers, plan.GatewayPolicies)...)
+}
+
+// dryRunPlan validates the planned Vessel with a server-side dry run.
+func (r *vessel) dryRunPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	dryrun.ModifyPlan(ctx, r.dryRun, dryrun.Resource[*formationv1.Vessel, vesselModel]{
+		Kind: "Vessel",
+		Client: func(plan vesselModel) dryrun.Client[*formationv1.Vessel] {
+			return r.clientSet.FormationV1().Vessels(plan.Environment.ValueString())
+		},
+		Name:     func(m vesselModel) string { return m.Name.ValueString() },
+		ToObject: vesselModel.ToObject,
+	}, req, resp)
 }
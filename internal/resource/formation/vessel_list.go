@@ -0,0 +1,39 @@
+package formation
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	formationv1 "github.com/gamefabric/gf-core/pkg/api/formation/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewVesselList returns a new instance of the Vessel list resource.
+func NewVesselList() list.ListResource {
+	return listresource.NewNamespaced(listresource.Options[formationv1.Vessel]{
+		TypeName: "vessel",
+		Plural:   "vessels",
+		Kinds:    "Vessels",
+		List: func(ctx context.Context, cs clientset.Interface, env string, opts metav1.ListOptions) ([]formationv1.Vessel, error) {
+			objs, err := cs.FormationV1().Vessels(env).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *formationv1.Vessel, defaults listresource.Defaults) listresource.Result {
+			m := newVesselModel(obj)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Namespaced{Environment: m.Environment, Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
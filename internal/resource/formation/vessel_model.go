@@ -7,6 +7,7 @@ import (
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/container"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,6 +22,8 @@ type vesselModel struct {
 	Suspend               types.Bool                         `tfsdk:"suspend"`
 	Labels                map[string]types.String            `tfsdk:"labels"`
 	Annotations           map[string]types.String            `tfsdk:"annotations"`
+	LabelsAll             map[string]types.String            `tfsdk:"labels_all"`
+	AnnotationsAll        map[string]types.String            `tfsdk:"annotations_all"`
 	GameServerLabels      map[string]types.String            `tfsdk:"gameserver_labels"`
 	GameServerAnnotations map[string]types.String            `tfsdk:"gameserver_annotations"`
 	Containers            []mps.ContainerModel               `tfsdk:"containers"`
@@ -30,6 +33,8 @@ type vesselModel struct {
 	GatewayPolicies       []types.String                     `tfsdk:"gateway_policies"`
 	ProfilingEnabled      types.Bool                         `tfsdk:"profiling_enabled"`
 	ImageUpdaterTarget    *container.ImageUpdaterTargetModel `tfsdk:"image_updater_target"`
+	WaitForReady          types.Bool                         `tfsdk:"wait_for_ready"`
+	Timeouts              timeouts.Value                     `tfsdk:"timeouts"`
 }
 
 func newVesselModel(obj *formationv1.Vessel) vesselModel {
@@ -42,6 +47,8 @@ func newVesselModel(obj *formationv1.Vessel) vesselModel {
 		Suspend:               conv.OptionalFunc(obj.Spec.Suspend, types.BoolPointerValue, types.BoolNull),
 		Labels:                conv.ForEachMapItem(obj.Labels, types.StringValue),
 		Annotations:           conv.ForEachMapItem(obj.Annotations, types.StringValue),
+		LabelsAll:             conv.ForEachMapItem(obj.Labels, types.StringValue),
+		AnnotationsAll:        conv.ForEachMapItem(obj.Annotations, types.StringValue),
 		GameServerLabels:      conv.ForEachMapItem(conv.MapWithoutKey(obj.Spec.Template.Labels, profilingKey), types.StringValue),
 		GameServerAnnotations: conv.ForEachMapItem(obj.Spec.Template.Annotations, types.StringValue),
 		Containers:            conv.ForEachSliceItem(obj.Spec.Template.Spec.Containers, mps.NewContainerForFormation),
@@ -51,6 +58,7 @@ func newVesselModel(obj *formationv1.Vessel) vesselModel {
 		GatewayPolicies:       conv.ForEachSliceItem(obj.Spec.Template.Spec.GatewayPolicies, types.StringValue),
 		ProfilingEnabled:      conv.BoolFromMapKey(obj.Spec.Template.Labels, profilingKey, types.BoolValue(false)),
 		ImageUpdaterTarget:    container.NewImageUpdaterTargetModel(container.ImageUpdaterTargetTypeVessel, obj.Name, obj.Environment),
+		Timeouts:              timeouts.Null(),
 	}
 }
 
@@ -59,8 +67,8 @@ func (m vesselModel) ToObject() *formationv1.Vessel {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
 			Environment: m.Environment.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(v types.String) string { return v.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(v types.String) string { return v.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(v types.String) string { return v.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(v types.String) string { return v.ValueString() }),
 		},
 		Spec: formationv1.VesselSpec{
 			Description: m.Description.ValueString(),
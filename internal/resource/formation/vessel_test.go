@@ -170,9 +170,9 @@ func TestResourceVessel_Validates(t *testing.T) {
 			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "name" is required`)),
 		},
 		{
-			name:        "requires environment",
-			config:      testResourceVesselConfigEmpty(),
-			expectError: regexp.MustCompile(regexp.QuoteMeta(`The argument "environment" is required`)),
+			name:        "requires environment without default environment",
+			config:      strings.Replace(testResourceVesselConfigBasic(), `environment = "test"`, "", 1),
+			expectError: regexp.MustCompile(regexp.QuoteMeta(`Missing Environment`)),
 		},
 		{
 			name:        "requires containers",
@@ -0,0 +1,145 @@
+package mps
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ValidateReferences looks up the ConfigFiles and Secrets in env that the containers
+// refer to, and the GatewayPolicies in gatewayPolicies. It returns a warning on the
+// attribute of each reference to an object or secret key that does not exist, as the
+// object may be created in the same apply.
+//
+// References that are not known yet are skipped, as are all container references
+// if env is not known yet.
+func ValidateReferences(ctx context.Context, cs clientset.Interface, env types.String, containers []ContainerModel, gatewayPolicies []types.String) diag.Diagnostics {
+	v := referenceValidator{
+		cs:      cs,
+		env:     env.ValueString(),
+		found:   map[string]bool{},
+		secrets: map[string]*corev1.Secret{},
+	}
+
+	if conv.IsKnown(env) {
+		for i, ctr := range containers {
+			ctrPath := path.Root("containers").AtListIndex(i)
+			for j, cf := range ctr.ConfigFiles {
+				v.configFile(ctx, cf.Name, ctrPath.AtName("config_files").AtListIndex(j).AtName("name"))
+			}
+			for j, s := range ctr.Secrets {
+				v.secret(ctx, s.Name, ctrPath.AtName("secrets").AtListIndex(j).AtName("name"))
+			}
+			for j, e := range ctr.Envs {
+				if e.ValueFrom == nil {
+					continue
+				}
+				srcPath := ctrPath.AtName("envs").AtListIndex(j).AtName("value_from")
+				v.configFile(ctx, e.ValueFrom.ConfigFile, srcPath.AtName("config_file"))
+				if e.ValueFrom.Secret != nil {
+					v.secretKey(ctx, e.ValueFrom.Secret.Name, e.ValueFrom.Secret.Key, srcPath.AtName("secret"))
+				}
+			}
+		}
+	}
+	for i, name := range gatewayPolicies {
+		v.gatewayPolicy(ctx, name, path.Root("gateway_policies").AtListIndex(i))
+	}
+	return v.diags
+}
+
+// referenceValidator looks up each referenced object once.
+type referenceValidator struct {
+	cs  clientset.Interface
+	env string
+
+	// found records whether an object exists by kind and name.
+	found   map[string]bool
+	secrets map[string]*corev1.Secret
+	diags   diag.Diagnostics
+}
+
+func (v *referenceValidator) configFile(ctx context.Context, name types.String, p path.Path) {
+	if !conv.IsKnown(name) {
+		return
+	}
+	if !v.exists("ConfigFile", name.ValueString(), p, func() error {
+		_, err := v.cs.CoreV1().ConfigFiles(v.env).Get(ctx, name.ValueString(), metav1.GetOptions{})
+		return err
+	}) {
+		v.diags.AddAttributeWarning(p, "Config File Not Found",
+			fmt.Sprintf("ConfigFile %q does not exist in environment %q.", name.ValueString(), v.env))
+	}
+}
+
+func (v *referenceValidator) secret(ctx context.Context, name types.String, p path.Path) {
+	if !conv.IsKnown(name) {
+		return
+	}
+	if !v.exists("Secret", name.ValueString(), p, func() error {
+		obj, err := v.cs.CoreV1().Secrets(v.env).Get(ctx, name.ValueString(), metav1.GetOptions{})
+		if err == nil {
+			v.secrets[name.ValueString()] = obj
+		}
+		return err
+	}) {
+		v.diags.AddAttributeWarning(p, "Secret Not Found",
+			fmt.Sprintf("Secret %q does not exist in environment %q.", name.ValueString(), v.env))
+	}
+}
+
+func (v *referenceValidator) secretKey(ctx context.Context, name, key types.String, p path.Path) {
+	v.secret(ctx, name, p.AtName("name"))
+
+	obj, ok := v.secrets[name.ValueString()]
+	if !ok || !conv.IsKnown(key) {
+		return
+	}
+	if _, ok = obj.Data[key.ValueString()]; !ok {
+		v.diags.AddAttributeWarning(p.AtName("key"), "Secret Key Not Found",
+			fmt.Sprintf("Secret %q in environment %q has no key %q.", name.ValueString(), v.env, key.ValueString()))
+	}
+}
+
+func (v *referenceValidator) gatewayPolicy(ctx context.Context, name types.String, p path.Path) {
+	if !conv.IsKnown(name) {
+		return
+	}
+	if !v.exists("GatewayPolicy", name.ValueString(), p, func() error {
+		_, err := v.cs.ProtectionV1().GatewayPolicies().Get(ctx, name.ValueString(), metav1.GetOptions{})
+		return err
+	}) {
+		v.diags.AddAttributeWarning(p, "Gateway Policy Not Found",
+			fmt.Sprintf("GatewayPolicy %q does not exist.", name.ValueString()))
+	}
+}
+
+// exists reports whether the named object exists, calling get on its first lookup.
+// An object that could not be looked up is reported as a warning and assumed to exist.
+func (v *referenceValidator) exists(kind, name string, p path.Path, get func() error) bool {
+	key := kind + "/" + name
+	if found, ok := v.found[key]; ok {
+		return found
+	}
+
+	err := get()
+	switch {
+	case err == nil:
+		v.found[key] = true
+	case apierrors.IsNotFound(err):
+		v.found[key] = false
+	default:
+		v.diags.AddAttributeWarning(p, "Could Not Validate Reference",
+			fmt.Sprintf("Could not look up %s %q: %v. A missing object is only reported during apply.", kind, name, err))
+		v.found[key] = true
+	}
+	return v.found[key]
+}
@@ -0,0 +1,77 @@
+package mps_test
+
+import (
+	"testing"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	corev1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	protectionv1 "github.com/gamefabric/gf-core/pkg/api/protection/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/fake"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/core"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resource/mps"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReferences(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New(
+		&corev1.ConfigFile{ObjectMeta: metav1.ObjectMeta{Name: "game-config", Environment: "dflt"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "game-secret", Environment: "dflt"}, Data: map[string]string{"token": "secret"}},
+		&protectionv1.GatewayPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+	)
+	require.NoError(t, err)
+
+	containers := []mps.ContainerModel{
+		{
+			ConfigFiles: []mps.ConfigFileModel{
+				{Name: types.StringValue("game-config")},
+				{Name: types.StringValue("missing-config")},
+				{Name: types.StringUnknown()},
+			},
+			Secrets: []mps.SecretMountModel{
+				{Name: types.StringValue("missing-secret")},
+			},
+			Envs: []core.EnvVarModel{
+				{ValueFrom: &core.EnvVarSourceModel{Secret: &core.SecretType{Name: types.StringValue("game-secret"), Key: types.StringValue("token")}}},
+				{ValueFrom: &core.EnvVarSourceModel{Secret: &core.SecretType{Name: types.StringValue("game-secret"), Key: types.StringValue("missing")}}},
+			},
+		},
+	}
+	policies := []types.String{types.StringValue("policy"), types.StringValue("missing-policy")}
+
+	got := mps.ValidateReferences(t.Context(), cs, types.StringValue("dflt"), containers, policies)
+
+	want := []path.Path{
+		path.Root("containers").AtListIndex(0).AtName("config_files").AtListIndex(1).AtName("name"),
+		path.Root("containers").AtListIndex(0).AtName("secrets").AtListIndex(0).AtName("name"),
+		path.Root("containers").AtListIndex(0).AtName("envs").AtListIndex(1).AtName("value_from").AtName("secret").AtName("key"),
+		path.Root("gateway_policies").AtListIndex(1),
+	}
+	require.Len(t, got, len(want))
+	for i, d := range got {
+		assert.Equal(t, diag.SeverityWarning, d.Severity())
+		pd, ok := d.(interface{ Path() path.Path })
+		require.True(t, ok)
+		assert.Equal(t, want[i], pd.Path())
+	}
+}
+
+func TestValidateReferences_UnknownEnvironment(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New()
+	require.NoError(t, err)
+
+	containers := []mps.ContainerModel{
+		{ConfigFiles: []mps.ConfigFileModel{{Name: types.StringValue("game-config")}}},
+	}
+
+	got := mps.ValidateReferences(t.Context(), cs, types.StringNull(), containers, nil)
+
+	assert.Empty(t, got)
+}
@@ -6,7 +6,6 @@ import (
 	"slices"
 	"strings"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -15,8 +14,13 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	receiverreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/notification/receiver"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -34,6 +38,7 @@ var (
 	_ resource.ResourceWithConfigure   = &receiver{}
 	_ resource.ResourceWithImportState = &receiver{}
 	_ resource.ResourceWithModifyPlan  = &receiver{}
+	_ resource.ResourceWithIdentity    = &receiver{}
 )
 
 var receiverValidator = validators.NewGameFabricValidator[*notificationv1alpha1.Receiver, receiverModel](func() validators.StoreValidator {
@@ -44,7 +49,10 @@ var receiverValidator = validators.NewGameFabricValidator[*notificationv1alpha1.
 })
 
 type receiver struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	configUnknown      bool
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewReceiverResource returns a new instance of the receiver resource.
@@ -97,6 +105,18 @@ func (r *receiver) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					&validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"email_to": schema.ListAttribute{
 				Description:         "The list of email addresses to send notifications to.",
 				MarkdownDescription: "The list of email addresses to send notifications to.",
@@ -111,6 +131,11 @@ func (r *receiver) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *receiver) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *receiver) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -127,6 +152,9 @@ func (r *receiver) Configure(_ context.Context, req resource.ConfigureRequest, r
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.configUnknown = procCtx.ConfigUnknown
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *receiver) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -137,6 +165,7 @@ func (r *receiver) Create(ctx context.Context, req resource.CreateRequest, resp
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "Receiver", obj)
 	outObj, err := r.clientSet.NotificationV1Alpha1().Receivers().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -146,9 +175,14 @@ func (r *receiver) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	labels, annotations := plan.Labels, plan.Annotations
 	plan = newReceiverModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *receiver) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -172,9 +206,14 @@ func (r *receiver) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	labels, annotations := state.Labels, state.Annotations
 	state = newReceiverModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 func (r *receiver) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -200,16 +239,28 @@ func (r *receiver) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	if _, err = r.clientSet.NotificationV1Alpha1().Receivers().Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Receiver", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.NotificationV1Alpha1().Receivers(), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Receiver", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Receiver",
 			fmt.Sprintf("Could not patch Receiver: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	labels, annotations := plan.Labels, plan.Annotations
+	plan = newReceiverModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *receiver) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -237,7 +288,9 @@ func (r *receiver) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	}
 }
 
-// ModifyPlan validates that a receiver is not still referenced by any CloudBudget before it is
+// ModifyPlan merges the provider's default labels and annotations.
+//
+// On destroy, it validates that a receiver is not still referenced by any CloudBudget before it is
 // deleted. Because the receivers list in gamefabric_cloudbudget holds plain name strings, Terraform
 // loses the dependency edge when the reference is removed from config together with the resource.
 // Without the edge, Terraform may delete the receiver before (or in parallel with) updating the
@@ -245,13 +298,17 @@ func (r *receiver) Delete(ctx context.Context, req resource.DeleteRequest, resp
 // apply the two-step fix: first remove the receiver from the CloudBudget's receivers list, then
 // delete the receiver resource.
 func (r *receiver) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Only run on destroy (plan is null, state has a value).
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+
+	// Only check references on destroy (plan is null, state has a value).
 	if !req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
 		return
 	}
 
-	// clientSet may be nil during unit tests that do not configure the provider.
-	if r.clientSet == nil {
+	// clientSet may be nil during unit tests that do not configure the provider,
+	// and the API cannot be reached while the provider configuration is unknown.
+	if r.clientSet == nil || r.configUnknown {
 		return
 	}
 
@@ -296,5 +353,5 @@ func (r *receiver) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 }
 
 func (r *receiver) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
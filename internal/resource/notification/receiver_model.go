@@ -8,11 +8,13 @@ import (
 )
 
 type receiverModel struct {
-	ID          types.String            `tfsdk:"id"`
-	Name        types.String            `tfsdk:"name"`
-	Labels      map[string]types.String `tfsdk:"labels"`
-	Annotations map[string]types.String `tfsdk:"annotations"`
-	EmailTo     []types.String          `tfsdk:"email_to"`
+	ID             types.String            `tfsdk:"id"`
+	Name           types.String            `tfsdk:"name"`
+	Labels         map[string]types.String `tfsdk:"labels"`
+	Annotations    map[string]types.String `tfsdk:"annotations"`
+	LabelsAll      map[string]types.String `tfsdk:"labels_all"`
+	AnnotationsAll map[string]types.String `tfsdk:"annotations_all"`
+	EmailTo        []types.String          `tfsdk:"email_to"`
 }
 
 func newReceiverModel(obj *notificationv1alpha1.Receiver) receiverModel {
@@ -22,11 +24,13 @@ func newReceiverModel(obj *notificationv1alpha1.Receiver) receiverModel {
 	}
 
 	return receiverModel{
-		ID:          types.StringValue(obj.Name),
-		Name:        types.StringValue(obj.Name),
-		Labels:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
-		Annotations: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
-		EmailTo:     conv.ForEachSliceItem(emailTo, func(item string) types.String { return types.StringValue(item) }),
+		ID:             types.StringValue(obj.Name),
+		Name:           types.StringValue(obj.Name),
+		Labels:         conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		Annotations:    conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		LabelsAll:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		AnnotationsAll: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		EmailTo:        conv.ForEachSliceItem(emailTo, func(item string) types.String { return types.StringValue(item) }),
 	}
 }
 
@@ -34,8 +38,8 @@ func (m receiverModel) ToObject() *notificationv1alpha1.Receiver {
 	return &notificationv1alpha1.Receiver{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: notificationv1alpha1.ReceiverSpec{
 			Email: &notificationv1alpha1.ReceiverEmail{
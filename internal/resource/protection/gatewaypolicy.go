@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -27,10 +31,14 @@ var (
 	_ resource.Resource                = &gatewayPolicy{}
 	_ resource.ResourceWithConfigure   = &gatewayPolicy{}
 	_ resource.ResourceWithImportState = &gatewayPolicy{}
+	_ resource.ResourceWithModifyPlan  = &gatewayPolicy{}
+	_ resource.ResourceWithIdentity    = &gatewayPolicy{}
 )
 
 type gatewayPolicy struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewGatewayPolicy returns a new instance of the gateway policy resource.
@@ -88,6 +96,18 @@ func (r *gatewayPolicy) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					&validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"description": schema.StringAttribute{
 				Description:         "Description is the optional description of the gateway policy.",
 				MarkdownDescription: "Description is the optional description of the gateway policy.",
@@ -107,6 +127,11 @@ func (r *gatewayPolicy) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *gatewayPolicy) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *gatewayPolicy) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -123,6 +148,8 @@ func (r *gatewayPolicy) Configure(_ context.Context, req resource.ConfigureReque
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *gatewayPolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -133,6 +160,7 @@ func (r *gatewayPolicy) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "GatewayPolicy", obj)
 	outObj, err := r.clientSet.ProtectionV1().GatewayPolicies().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -142,9 +170,14 @@ func (r *gatewayPolicy) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	labels, annotations := plan.Labels, plan.Annotations
 	plan = newGatewayPolicyModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *gatewayPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -168,9 +201,14 @@ func (r *gatewayPolicy) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	labels, annotations := state.Labels, state.Annotations
 	state = newGatewayPolicyModel(outObj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 func (r *gatewayPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -196,16 +234,28 @@ func (r *gatewayPolicy) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	if _, err = r.clientSet.ProtectionV1().GatewayPolicies().Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "GatewayPolicy", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.ProtectionV1().GatewayPolicies(), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Gateway Policy", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Gateway Policy",
 			fmt.Sprintf("Could not patch GatewayPolicy: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	labels, annotations := plan.Labels, plan.Annotations
+	plan = newGatewayPolicyModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *gatewayPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -233,6 +283,12 @@ func (r *gatewayPolicy) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ModifyPlan merges the provider's default labels and annotations.
+func (r *gatewayPolicy) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+}
+
 func (r *gatewayPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
@@ -0,0 +1,39 @@
+package protection
+
+import (
+	"context"
+
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	protectionv1 "github.com/gamefabric/gf-core/pkg/api/protection/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/listresource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewGatewayPolicyList returns a new instance of the GatewayPolicy list resource.
+func NewGatewayPolicyList() list.ListResource {
+	return listresource.NewCluster(listresource.Options[protectionv1.GatewayPolicy]{
+		TypeName: "protection_gatewaypolicy",
+		Plural:   "gateway policies",
+		Kinds:    "GatewayPolicies",
+		List: func(ctx context.Context, cs clientset.Interface, _ string, opts metav1.ListOptions) ([]protectionv1.GatewayPolicy, error) {
+			objs, err := cs.ProtectionV1().GatewayPolicies().List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return objs.Items, nil
+		},
+		Result: func(obj *protectionv1.GatewayPolicy, defaults listresource.Defaults) listresource.Result {
+			m := newGatewayPolicyModel(obj)
+			m.Labels = conv.WithoutDefaults(m.LabelsAll, nil, defaults.Labels)
+			m.Annotations = conv.WithoutDefaults(m.AnnotationsAll, nil, defaults.Annotations)
+			return listresource.Result{
+				DisplayName: m.ID.ValueString(),
+				Identity:    identity.Cluster{Name: m.Name},
+				Resource:    m,
+			}
+		},
+	})
+}
@@ -12,6 +12,8 @@ type gatewayPolicyModel struct {
 	Name             types.String            `tfsdk:"name"`
 	Labels           map[string]types.String `tfsdk:"labels"`
 	Annotations      map[string]types.String `tfsdk:"annotations"`
+	LabelsAll        map[string]types.String `tfsdk:"labels_all"`
+	AnnotationsAll   map[string]types.String `tfsdk:"annotations_all"`
 	DisplayName      types.String            `tfsdk:"display_name"`
 	Description      types.String            `tfsdk:"description"`
 	DestinationCIDRs []types.String          `tfsdk:"destination_cidrs"`
@@ -23,6 +25,8 @@ func newGatewayPolicyModel(obj *protectionv1.GatewayPolicy) gatewayPolicyModel {
 		Name:             types.StringValue(obj.Name),
 		Labels:           conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
 		Annotations:      conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		LabelsAll:        conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		AnnotationsAll:   conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
 		DisplayName:      conv.OptionalFunc(obj.Spec.DisplayName, types.StringValue, types.StringNull),
 		Description:      conv.OptionalFunc(obj.Spec.Description, types.StringValue, types.StringNull),
 		DestinationCIDRs: conv.ForEachSliceItem(obj.Spec.DestinationCIDRs, func(item string) types.String { return types.StringValue(item) }),
@@ -33,8 +37,8 @@ func (m gatewayPolicyModel) ToObject() *protectionv1.GatewayPolicy {
 	return &protectionv1.GatewayPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: protectionv1.GatewayPolicySpec{
 			DisplayName:      m.DisplayName.ValueString(),
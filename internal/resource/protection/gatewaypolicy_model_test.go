@@ -49,6 +49,12 @@ var (
 		Annotations: map[string]types.String{
 			"annotation-key": types.StringValue("annotation-value"),
 		},
+		LabelsAll: map[string]types.String{
+			"label-key": types.StringValue("label-value"),
+		},
+		AnnotationsAll: map[string]types.String{
+			"annotation-key": types.StringValue("annotation-value"),
+		},
 		DisplayName: types.StringValue("Test Gateway Policy"),
 		Description: types.StringValue("Test Gateway Policy Description"),
 		DestinationCIDRs: []types.String{
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/planmodifiers"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -26,10 +30,14 @@ var (
 	_ resource.Resource                = &group{}
 	_ resource.ResourceWithConfigure   = &group{}
 	_ resource.ResourceWithImportState = &group{}
+	_ resource.ResourceWithModifyPlan  = &group{}
+	_ resource.ResourceWithIdentity    = &group{}
 )
 
 type group struct {
-	clientSet clientset.Interface
+	clientSet          clientset.Interface
+	defaultLabels      types.Map
+	defaultAnnotations types.Map
 }
 
 // NewGroup returns a new group resource.
@@ -81,6 +89,18 @@ func (r *group) Schema(_ context.Context, _ resource.SchemaRequest, resp *resour
 					validators.AnnotationsValidator{},
 				},
 			},
+			"labels_all": schema.MapAttribute{
+				Description:         "All labels of the object, including the provider's default_labels.",
+				MarkdownDescription: "All labels of the object, including the provider's `default_labels`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				Description:         "All annotations of the object, including the provider's default_annotations.",
+				MarkdownDescription: "All annotations of the object, including the provider's `default_annotations`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"users": schema.ListAttribute{
 				Description:         "The users that are part of the group.",
 				MarkdownDescription: "The users that are part of the group.",
@@ -91,6 +111,11 @@ func (r *group) Schema(_ context.Context, _ resource.SchemaRequest, resp *resour
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *group) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 // Configure prepares the struct.
 func (r *group) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -107,6 +132,8 @@ func (r *group) Configure(_ context.Context, req resource.ConfigureRequest, resp
 	}
 
 	r.clientSet = procCtx.ClientSet
+	r.defaultLabels = procCtx.DefaultLabels
+	r.defaultAnnotations = procCtx.DefaultAnnotations
 }
 
 func (r *group) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -117,6 +144,7 @@ func (r *group) Create(ctx context.Context, req resource.CreateRequest, resp *re
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "Group", obj)
 	outObj, err := r.clientSet.RBACV1().Groups().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -126,9 +154,14 @@ func (r *group) Create(ctx context.Context, req resource.CreateRequest, resp *re
 		return
 	}
 
+	labels, annotations := plan.Labels, plan.Annotations
 	plan = newGroupModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *group) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -152,9 +185,14 @@ func (r *group) Read(ctx context.Context, req resource.ReadRequest, resp *resour
 		return
 	}
 
+	labels, annotations := state.Labels, state.Annotations
 	state = newGroupModel(obj)
+	state.Labels = conv.WithoutDefaults(state.LabelsAll, labels, r.defaultLabels)
+	state.Annotations = conv.WithoutDefaults(state.AnnotationsAll, annotations, r.defaultAnnotations)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, obj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: state.Name})...)
 }
 
 func (r *group) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -180,16 +218,28 @@ func (r *group) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 		return
 	}
 
-	if _, err = r.clientSet.RBACV1().Groups().Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "Group", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.RBACV1().Groups(), newObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Group", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Group",
 			fmt.Sprintf("Could not patch Group: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	labels, annotations := plan.Labels, plan.Annotations
+	plan = newGroupModel(outObj)
+	plan.Labels = conv.WithoutDefaults(plan.LabelsAll, labels, r.defaultLabels)
+	plan.Annotations = conv.WithoutDefaults(plan.AnnotationsAll, annotations, r.defaultAnnotations)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.Name})...)
 }
 
 func (r *group) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -217,6 +267,12 @@ func (r *group) Delete(ctx context.Context, req resource.DeleteRequest, resp *re
 	}
 }
 
+// ModifyPlan merges the provider's default labels and annotations.
+func (r *group) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planmodifiers.MergeDefaults(ctx, path.Root("labels"), path.Root("labels_all"), r.defaultLabels, req, resp)
+	planmodifiers.MergeDefaults(ctx, path.Root("annotations"), path.Root("annotations_all"), r.defaultAnnotations, req, resp)
+}
+
 func (r *group) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("name"))
 }
@@ -8,20 +8,24 @@ import (
 )
 
 type groupModel struct {
-	ID          types.String            `tfsdk:"id"`
-	Name        types.String            `tfsdk:"name"`
-	Labels      map[string]types.String `tfsdk:"labels"`
-	Annotations map[string]types.String `tfsdk:"annotations"`
-	Users       []types.String          `tfsdk:"users"`
+	ID             types.String            `tfsdk:"id"`
+	Name           types.String            `tfsdk:"name"`
+	Labels         map[string]types.String `tfsdk:"labels"`
+	Annotations    map[string]types.String `tfsdk:"annotations"`
+	LabelsAll      map[string]types.String `tfsdk:"labels_all"`
+	AnnotationsAll map[string]types.String `tfsdk:"annotations_all"`
+	Users          []types.String          `tfsdk:"users"`
 }
 
 func newGroupModel(obj *rbacv1.Group) groupModel {
 	return groupModel{
-		ID:          types.StringValue(obj.Name),
-		Name:        types.StringValue(obj.Name),
-		Labels:      conv.ForEachMapItem(obj.Labels, types.StringValue),
-		Annotations: conv.ForEachMapItem(obj.Annotations, types.StringValue),
-		Users:       conv.ForEachSliceItem(obj.Users, types.StringValue),
+		ID:             types.StringValue(obj.Name),
+		Name:           types.StringValue(obj.Name),
+		Labels:         conv.ForEachMapItem(obj.Labels, types.StringValue),
+		Annotations:    conv.ForEachMapItem(obj.Annotations, types.StringValue),
+		LabelsAll:      conv.ForEachMapItem(obj.Labels, types.StringValue),
+		AnnotationsAll: conv.ForEachMapItem(obj.Annotations, types.StringValue),
+		Users:          conv.ForEachSliceItem(obj.Users, types.StringValue),
 	}
 }
 
@@ -29,8 +33,8 @@ func (m groupModel) ToObject() *rbacv1.Group {
 	return &rbacv1.Group{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Users: conv.ForEachSliceItem(m.Users, func(item types.String) string { return item.ValueString() }),
 	}
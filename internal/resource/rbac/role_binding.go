@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -13,8 +12,11 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/rbac/rolebinding"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -30,6 +32,7 @@ var (
 	_ resource.Resource                = &roleBinding{}
 	_ resource.ResourceWithConfigure   = &roleBinding{}
 	_ resource.ResourceWithImportState = &roleBinding{}
+	_ resource.ResourceWithIdentity    = &roleBinding{}
 )
 
 var roleBindingValidator = validators.NewGameFabricValidator[*rbacv1.RoleBinding, roleBindingModel](func() validators.StoreValidator {
@@ -93,6 +96,11 @@ func (r *roleBinding) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *roleBinding) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.ClusterSchema()
+}
+
 func (r *roleBinding) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -118,6 +126,7 @@ func (r *roleBinding) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "RoleBinding", obj)
 	outObj, err := r.clientSet.RBACV1().RoleBindings().Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError("Error Creating Role Binding", fmt.Sprintf("Could not create Role Binding: %s", err))
@@ -127,6 +136,8 @@ func (r *roleBinding) Create(ctx context.Context, req resource.CreateRequest, re
 	plan = newRoleBindingModel(outObj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.ID})...)
 }
 
 func (r *roleBinding) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -153,6 +164,8 @@ func (r *roleBinding) Read(ctx context.Context, req resource.ReadRequest, resp *
 	updatedState := newRoleBindingModel(obj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &updatedState, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, obj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: updatedState.ID})...)
 }
 
 func (r *roleBinding) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -178,16 +191,25 @@ func (r *roleBinding) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	if _, err = r.clientSet.RBACV1().RoleBindings().Patch(ctx, oldObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{}); err != nil {
+	logging.Patch(ctx, "RoleBinding", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.RBACV1().RoleBindings(), oldObj.Name, pb)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("Role Binding", oldObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching Role Binding",
 			fmt.Sprintf("Could not patch Role Binding: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(newObj.Name)
+	plan = newRoleBindingModel(outObj)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Cluster{Name: plan.ID})...)
 }
 
 func (r *roleBinding) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -216,5 +238,5 @@ func (r *roleBinding) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *roleBinding) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	identity.ImportCluster(ctx, req, resp, path.Root("id"))
 }
@@ -5,28 +5,36 @@ import (
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	storagev1beta1 "github.com/gamefabric/gf-core/pkg/api/storage/v1beta1"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type volumeModel struct {
-	ID          types.String            `tfsdk:"id"`
-	Name        types.String            `tfsdk:"name"`
-	Environment types.String            `tfsdk:"environment"`
-	Labels      map[string]types.String `tfsdk:"labels"`
-	Annotations map[string]types.String `tfsdk:"annotations"`
-	VolumeStore types.String            `tfsdk:"volume_store"`
-	Capacity    types.String            `tfsdk:"capacity"`
+	ID                 types.String            `tfsdk:"id"`
+	Name               types.String            `tfsdk:"name"`
+	Environment        types.String            `tfsdk:"environment"`
+	Labels             map[string]types.String `tfsdk:"labels"`
+	Annotations        map[string]types.String `tfsdk:"annotations"`
+	LabelsAll          map[string]types.String `tfsdk:"labels_all"`
+	AnnotationsAll     map[string]types.String `tfsdk:"annotations_all"`
+	VolumeStore        types.String            `tfsdk:"volume_store"`
+	Capacity           types.String            `tfsdk:"capacity"`
+	DeletionProtection types.Bool              `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value          `tfsdk:"timeouts"`
 }
 
 func newVolumeModel(obj *storagev1beta1.Volume) volumeModel {
 	return volumeModel{
-		ID:          types.StringValue(cache.NewObjectName(obj.Environment, obj.Name).String()),
-		Name:        types.StringValue(obj.Name),
-		Environment: types.StringValue(obj.Environment),
-		Labels:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
-		Annotations: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
-		VolumeStore: types.StringValue(obj.Spec.VolumeStoreName),
-		Capacity:    types.StringValue(obj.Spec.Capacity.String()),
+		ID:             types.StringValue(cache.NewObjectName(obj.Environment, obj.Name).String()),
+		Name:           types.StringValue(obj.Name),
+		Environment:    types.StringValue(obj.Environment),
+		Labels:         conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		Annotations:    conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		LabelsAll:      conv.ForEachMapItem(obj.Labels, func(item string) types.String { return types.StringValue(item) }),
+		AnnotationsAll: conv.ForEachMapItem(obj.Annotations, func(item string) types.String { return types.StringValue(item) }),
+		VolumeStore:    types.StringValue(obj.Spec.VolumeStoreName),
+		Capacity:       types.StringValue(obj.Spec.Capacity.String()),
+		Timeouts:       timeouts.Null(),
 	}
 }
 
@@ -35,8 +43,8 @@ func (m volumeModel) ToObject() *storagev1beta1.Volume {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        m.Name.ValueString(),
 			Environment: m.Environment.ValueString(),
-			Labels:      conv.ForEachMapItem(m.Labels, func(item types.String) string { return item.ValueString() }),
-			Annotations: conv.ForEachMapItem(m.Annotations, func(item types.String) string { return item.ValueString() }),
+			Labels:      conv.ForEachMapItem(conv.MergeMaps(m.LabelsAll, m.Labels), func(item types.String) string { return item.ValueString() }),
+			Annotations: conv.ForEachMapItem(conv.MergeMaps(m.AnnotationsAll, m.Annotations), func(item types.String) string { return item.ValueString() }),
 		},
 		Spec: storagev1beta1.VolumeSpec{
 			VolumeStoreName: m.VolumeStore.ValueString(),
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gamefabric/gf-apiclient/rest"
-	"github.com/gamefabric/gf-apiclient/tools/cache"
 	"github.com/gamefabric/gf-apiclient/tools/patch"
 	apierrors "github.com/gamefabric/gf-apicore/api/errors"
 	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
@@ -14,8 +12,11 @@ import (
 	"github.com/gamefabric/gf-core/pkg/apiclient/clientset"
 	"github.com/gamefabric/gf-core/pkg/apiserver/registry/registrytest"
 	policyreg "github.com/gamefabric/gf-core/pkg/apiserver/registry/storage/volumestoreretentionpolicy"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/identity"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/logging"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/normalize"
 	provcontext "github.com/gamefabric/terraform-provider-gamefabric/internal/provider/context"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
 	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -31,6 +32,7 @@ var (
 	_ resource.Resource                = &volumeStoreRetentionPolicy{}
 	_ resource.ResourceWithConfigure   = &volumeStoreRetentionPolicy{}
 	_ resource.ResourceWithImportState = &volumeStoreRetentionPolicy{}
+	_ resource.ResourceWithIdentity    = &volumeStoreRetentionPolicy{}
 )
 
 var retPolicyValidator = validators.NewGameFabricValidator[*storagev1beta1.VolumeStoreRetentionPolicy, volumeStoreRetentionPolicyModel](
@@ -139,6 +141,11 @@ func (r *volumeStoreRetentionPolicy) Schema(_ context.Context, _ resource.Schema
 	}
 }
 
+// IdentitySchema defines the identity schema for this resource.
+func (r *volumeStoreRetentionPolicy) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identity.NamespacedSchema()
+}
+
 // Configure prepares the struct.
 func (r *volumeStoreRetentionPolicy) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -165,6 +172,7 @@ func (r *volumeStoreRetentionPolicy) Create(ctx context.Context, req resource.Cr
 	}
 
 	obj := plan.ToObject()
+	logging.Object(ctx, "VolumeStoreRetentionPolicy", obj)
 	outObj, err := r.clientSet.StorageV1Beta1().VolumeStoreRetentionPolicies(obj.Environment).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -177,6 +185,8 @@ func (r *volumeStoreRetentionPolicy) Create(ctx context.Context, req resource.Cr
 	plan = newVolumeStoreRetentionPolicyModel(outObj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.VolumeStore})...)
 }
 
 func (r *volumeStoreRetentionPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -205,6 +215,8 @@ func (r *volumeStoreRetentionPolicy) Read(ctx context.Context, req resource.Read
 	state = newVolumeStoreRetentionPolicyModel(outObj)
 	resp.Diagnostics.Append(normalize.Model(ctx, &state, req.State)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: state.Environment, Name: state.VolumeStore})...)
 }
 
 func (r *volumeStoreRetentionPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -230,19 +242,25 @@ func (r *volumeStoreRetentionPolicy) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	_, err = r.clientSet.StorageV1Beta1().
-		VolumeStoreRetentionPolicies(newObj.Environment).
-		Patch(ctx, newObj.Name, rest.MergePatchType, pb, metav1.UpdateOptions{})
+	logging.Patch(ctx, "VolumeStoreRetentionPolicy", pb)
+	outObj, err := resourceversion.Patch(ctx, req.Private, r.clientSet.StorageV1Beta1().VolumeStoreRetentionPolicies(newObj.Environment), newObj.Name, pb)
 	if err != nil {
+		if apierrors.IsConflict(err) {
+			resp.Diagnostics.Append(resourceversion.Conflict("VolumeStoreRetentionPolicy", newObj.Name))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Patching VolumeStoreRetentionPolicy",
 			fmt.Sprintf("Could not patch VolumeStoreRetentionPolicy: %v", err),
 		)
 		return
 	}
+	resp.Diagnostics.Append(resourceversion.Set(ctx, resp.Private, outObj)...)
 
-	plan.ID = types.StringValue(cache.NewObjectName(newObj.Environment, newObj.Name).String())
+	plan = newVolumeStoreRetentionPolicyModel(outObj)
+	resp.Diagnostics.Append(normalize.Model(ctx, &plan, req.Plan)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, identity.Namespaced{Environment: plan.Environment, Name: plan.VolumeStore})...)
 }
 
 func (r *volumeStoreRetentionPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -273,11 +291,5 @@ func (r *volumeStoreRetentionPolicy) Delete(ctx context.Context, req resource.De
 }
 
 func (r *volumeStoreRetentionPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if req.ID == "" {
-		return
-	}
-
-	env, name := cache.SplitMetaNamespaceKey(req.ID)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("volume_store"), name)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), env)...)
+	identity.ImportNamespaced(ctx, req, resp, path.Root("volume_store"), types.StringNull())
 }
@@ -0,0 +1,203 @@
+// Package resourceversion tracks the last seen resource version of an object in the private
+// state of a resource and adds it to patches as a precondition, so that changes made outside
+// of Terraform between plan and apply are not silently overwritten.
+package resourceversion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gamefabric/gf-apiclient/rest"
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	privateKey        = "resource_version"
+	privateContentKey = "content_hash"
+)
+
+// maxAttempts is the number of times a patch is sent while only the status
+// of the object changes in between.
+const maxAttempts = 5
+
+// PrivateState is the private state of a resource.
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// Client reads and patches objects of type T.
+type Client[T any] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Patch(ctx context.Context, name string, pt rest.PatchType, data []byte, opts metav1.UpdateOptions) (T, error)
+}
+
+// Set stores the resource version and a hash of the content of obj in the private state.
+func Set(ctx context.Context, private PrivateState, obj any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rv, hash, err := fingerprint(obj)
+	if err != nil {
+		diags.AddError("Error Storing Resource Version", fmt.Sprintf("Could not read resource version: %v", err))
+		return diags
+	}
+	if rv == "" {
+		return nil
+	}
+
+	for key, val := range map[string]string{privateKey: rv, privateContentKey: hash} {
+		b, err := json.Marshal(val)
+		if err != nil {
+			diags.AddError("Error Storing Resource Version", fmt.Sprintf("Could not encode resource version: %v", err))
+			return diags
+		}
+		diags.Append(private.SetKey(ctx, key, b)...)
+	}
+	return diags
+}
+
+// Get returns the resource version stored in the private state.
+// It returns an empty string if none is stored.
+func Get(ctx context.Context, private PrivateState) (string, diag.Diagnostics) {
+	return getKey(ctx, private, privateKey)
+}
+
+func getKey(ctx context.Context, private PrivateState, key string) (string, diag.Diagnostics) {
+	b, diags := private.GetKey(ctx, key)
+	if diags.HasError() || len(b) == 0 {
+		return "", diags
+	}
+
+	var val string
+	if err := json.Unmarshal(b, &val); err != nil {
+		diags.AddError("Error Reading Resource Version", fmt.Sprintf("Could not decode resource version: %v", err))
+		return "", diags
+	}
+	return val, diags
+}
+
+// Patch applies the merge patch pb to the named object, with the resource version stored
+// in the private state as a precondition. The API rejects the patch with a conflict if the
+// object was changed since.
+//
+// A conflict caused by a status update is not reported: if the object is unchanged
+// apart from its status and resource version, the patch is sent again against the
+// latest resource version. An empty patch is not sent, the object is read instead.
+func Patch[T any](ctx context.Context, private PrivateState, client Client[T], name string, pb []byte) (T, error) {
+	var zero T
+
+	if isEmpty(pb) {
+		return client.Get(ctx, name, metav1.GetOptions{})
+	}
+
+	rv, diags := getKey(ctx, private, privateKey)
+	hash, hashDiags := getKey(ctx, private, privateContentKey)
+	diags.Append(hashDiags...)
+	if diags.HasError() {
+		return zero, diagsError(diags)
+	}
+
+	for attempt := 1; ; attempt++ {
+		data := pb
+		if rv != "" {
+			var err error
+			if data, err = withResourceVersion(pb, rv); err != nil {
+				return zero, fmt.Errorf("adding resource version to patch: %w", err)
+			}
+		}
+
+		obj, err := client.Patch(ctx, name, rest.MergePatchType, data, metav1.UpdateOptions{})
+		if !apierrors.IsConflict(err) || hash == "" || attempt == maxAttempts {
+			return obj, err
+		}
+
+		cur, getErr := client.Get(ctx, name, metav1.GetOptions{})
+		if getErr != nil {
+			return obj, err
+		}
+		curRV, curHash, fpErr := fingerprint(cur)
+		if fpErr != nil || curHash != hash {
+			return obj, err
+		}
+		rv = curRV
+	}
+}
+
+func isEmpty(pb []byte) bool {
+	p := map[string]any{}
+	if err := json.Unmarshal(pb, &p); err != nil {
+		return false
+	}
+	return len(p) == 0
+}
+
+func withResourceVersion(pb []byte, rv string) ([]byte, error) {
+	p := map[string]any{}
+	if len(pb) > 0 {
+		if err := json.Unmarshal(pb, &p); err != nil {
+			return nil, err
+		}
+	}
+
+	meta, ok := p["metadata"].(map[string]any)
+	if !ok {
+		meta = map[string]any{}
+		p["metadata"] = meta
+	}
+	meta["resourceVersion"] = rv
+
+	return json.Marshal(p)
+}
+
+// fingerprint returns the resource version of obj and a hash of the content managed
+// by Terraform, which excludes the status and all metadata but labels and annotations.
+func fingerprint(obj any) (string, string, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", "", err
+	}
+	o := map[string]any{}
+	if err = json.Unmarshal(b, &o); err != nil {
+		return "", "", err
+	}
+
+	meta, _ := o["metadata"].(map[string]any)
+	rv, _ := meta["resourceVersion"].(string)
+
+	delete(o, "status")
+	o["metadata"] = map[string]any{
+		"labels":      meta["labels"],
+		"annotations": meta["annotations"],
+	}
+	// Maps are encoded with sorted keys, so equal content has an equal hash.
+	b, err = json.Marshal(o)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256(b)
+	return rv, hex.EncodeToString(sum[:]), nil
+}
+
+func diagsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
+
+// Conflict returns the diagnostic for a patch of the object that was rejected
+// because the object was changed since Terraform last read it.
+func Conflict(kind, name string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		kind+" Changed Outside of Terraform",
+		fmt.Sprintf("%s %q was changed after Terraform last read it, and applying the plan would overwrite that change. "+
+			"Refresh the state and create a new plan to review the change, for example by running 'terraform apply' again.", kind, name),
+	)
+}
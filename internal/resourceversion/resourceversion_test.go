@@ -0,0 +1,164 @@
+package resourceversion_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gamefabric/gf-apiclient/rest"
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/resourceversion"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPrivateState map[string][]byte
+
+func (s testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return s[key], nil
+}
+
+func (s testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	s[key] = value
+	return nil
+}
+
+func TestSetGet(t *testing.T) {
+	t.Parallel()
+
+	private := testPrivateState{}
+
+	diags := resourceversion.Set(t.Context(), private, testObj("123", "spec", ""))
+	require.False(t, diags.HasError())
+
+	got, diags := resourceversion.Get(t.Context(), private)
+	require.False(t, diags.HasError())
+	assert.Equal(t, "123", got)
+}
+
+func TestPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		private     bool
+		patch       string
+		client      *testClient
+		wantPatches []string
+		wantGets    int
+		wantErr     bool
+	}{
+		{
+			name:        "adds resource version",
+			private:     true,
+			patch:       `{"spec":"new"}`,
+			client:      &testClient{},
+			wantPatches: []string{`{"metadata":{"resourceVersion":"1"},"spec":"new"}`},
+		},
+		{
+			name:        "leaves patch without resource version",
+			patch:       `{"spec":"new"}`,
+			client:      &testClient{},
+			wantPatches: []string{`{"spec":"new"}`},
+		},
+		{
+			name:     "reads object for empty patch",
+			private:  true,
+			patch:    `{}`,
+			client:   &testClient{obj: testObj("1", "old", "")},
+			wantGets: 1,
+		},
+		{
+			name:    "retries after status change",
+			private: true,
+			patch:   `{"spec":"new"}`,
+			client:  &testClient{conflicts: 1, obj: testObj("2", "old", "ready")},
+			wantPatches: []string{
+				`{"metadata":{"resourceVersion":"1"},"spec":"new"}`,
+				`{"metadata":{"resourceVersion":"2"},"spec":"new"}`,
+			},
+			wantGets: 1,
+		},
+		{
+			name:        "reports spec change",
+			private:     true,
+			patch:       `{"spec":"new"}`,
+			client:      &testClient{conflicts: 1, obj: testObj("2", "changed", "")},
+			wantPatches: []string{`{"metadata":{"resourceVersion":"1"},"spec":"new"}`},
+			wantGets:    1,
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			private := testPrivateState{}
+			if test.private {
+				diags := resourceversion.Set(t.Context(), private, testObj("1", "old", ""))
+				require.False(t, diags.HasError())
+			}
+
+			_, err := resourceversion.Patch(t.Context(), private, test.client, "test", []byte(test.patch))
+
+			if test.wantErr {
+				assert.True(t, apierrors.IsConflict(err))
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, test.client.patches, len(test.wantPatches))
+			for i, want := range test.wantPatches {
+				assert.JSONEq(t, want, test.client.patches[i])
+			}
+			assert.Equal(t, test.wantGets, test.client.gets)
+		})
+	}
+}
+
+type testObject struct {
+	Metadata testMeta `json:"metadata"`
+	Spec     string   `json:"spec"`
+	Status   string   `json:"status,omitempty"`
+}
+
+type testMeta struct {
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+func testObj(rv, spec, status string) *testObject {
+	return &testObject{Metadata: testMeta{ResourceVersion: rv}, Spec: spec, Status: status}
+}
+
+// testClient records the patches, rejecting the first conflicts of them,
+// and returns obj when read.
+type testClient struct {
+	obj       *testObject
+	conflicts int
+
+	patches []string
+	gets    int
+}
+
+func (c *testClient) Get(context.Context, string, metav1.GetOptions) (*testObject, error) {
+	c.gets++
+	if c.obj == nil {
+		return nil, errors.New("unexpected call")
+	}
+	return c.obj, nil
+}
+
+func (c *testClient) Patch(_ context.Context, _ string, _ rest.PatchType, data []byte, _ metav1.UpdateOptions) (*testObject, error) {
+	c.patches = append(c.patches, string(data))
+	if len(c.patches) <= c.conflicts {
+		return nil, &apierrors.StatusError{ErrStatus: metav1.Status{
+			Status: metav1.StatusFailure,
+			Code:   http.StatusConflict,
+			Reason: metav1.StatusReasonConflict,
+		}}
+	}
+	return &testObject{}, nil
+}
@@ -0,0 +1,46 @@
+package tfutils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// GetKnown reads the values of plan into target, reading values that are only
+// known after apply as null.
+func GetKnown(ctx context.Context, plan tfsdk.Plan, target any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	raw, err := tftypes.Transform(plan.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		diags.AddError("Error Reading Plan", fmt.Sprintf("Could not read the known values of the plan: %v", err))
+		return diags
+	}
+
+	known := tfsdk.Plan{Schema: plan.Schema, Raw: raw}
+	return known.Get(ctx, target)
+}
+
+// UnknownAttributes returns the names of the top-level attributes of plan
+// whose values are not fully known until apply.
+func UnknownAttributes(plan tfsdk.Plan, names ...string) []string {
+	var unknown []string
+	for _, name := range names {
+		v, _, err := tftypes.WalkAttributePath(plan.Raw, tftypes.NewAttributePath().WithAttributeName(name))
+		if err != nil {
+			continue
+		}
+		if val, ok := v.(tftypes.Value); ok && !val.IsFullyKnown() {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
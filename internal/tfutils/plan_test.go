@@ -0,0 +1,94 @@
+package tfutils_test
+
+import (
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/tfutils"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKnown(t *testing.T) {
+	t.Parallel()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{Required: true},
+			"id":   schema.StringAttribute{Computed: true},
+			"ports": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int64Attribute{Optional: true},
+					},
+				},
+			},
+		},
+	}
+	typ := s.Type().TerraformType(t.Context()).(tftypes.Object)
+	portsTyp := typ.AttributeTypes["ports"]
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw: tftypes.NewValue(typ, map[string]tftypes.Value{
+			"name":  tftypes.NewValue(tftypes.String, "test"),
+			"id":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"ports": tftypes.NewValue(portsTyp, tftypes.UnknownValue),
+		}),
+	}
+
+	var got struct {
+		Name  types.String `tfsdk:"name"`
+		ID    types.String `tfsdk:"id"`
+		Ports []struct {
+			Port types.Int64 `tfsdk:"port"`
+		} `tfsdk:"ports"`
+	}
+	diags := tfutils.GetKnown(t.Context(), plan, &got)
+
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.StringValue("test"), got.Name)
+	assert.True(t, got.ID.IsNull())
+	assert.Nil(t, got.Ports)
+}
+
+func TestUnknownAttributes(t *testing.T) {
+	t.Parallel()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{Required: true},
+			"id":   schema.StringAttribute{Computed: true},
+			"ports": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int64Attribute{Optional: true},
+					},
+				},
+			},
+		},
+	}
+	typ := s.Type().TerraformType(t.Context()).(tftypes.Object)
+	portsTyp := typ.AttributeTypes["ports"].(tftypes.List)
+	portTyp := portsTyp.ElementType.(tftypes.Object)
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw: tftypes.NewValue(typ, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "test"),
+			"id":   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"ports": tftypes.NewValue(portsTyp, []tftypes.Value{
+				tftypes.NewValue(portTyp, map[string]tftypes.Value{
+					"port": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+				}),
+			}),
+		}),
+	}
+
+	got := tfutils.UnknownAttributes(plan, "name", "ports", "missing")
+
+	assert.Equal(t, []string{"ports"}, got)
+}
@@ -0,0 +1,63 @@
+// Package timeouts provides the timeouts block of resources that wait for GameFabric objects,
+// which bounds the create, update and delete operations including all waits.
+package timeouts
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Value is the timeouts block of a resource.
+type Value = timeouts.Value
+
+// Block returns the schema of the timeouts block.
+func Block(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// Null returns an unset timeouts block, for models built from API objects.
+func Null() Value {
+	return Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+}
+
+// Create returns a context bounded by the create timeout of v.
+// The context is unbounded if the timeout is not set.
+func Create(ctx context.Context, v Value) (context.Context, context.CancelFunc) {
+	// The value is checked by the schema validator.
+	d, _ := v.Create(ctx, 0)
+	return withTimeout(ctx, d)
+}
+
+// Update returns a context bounded by the update timeout of v.
+// The context is unbounded if the timeout is not set.
+func Update(ctx context.Context, v Value) (context.Context, context.CancelFunc) {
+	d, _ := v.Update(ctx, 0)
+	return withTimeout(ctx, d)
+}
+
+// Delete returns a context bounded by the delete timeout of v.
+// The context is unbounded if the timeout is not set.
+func Delete(ctx context.Context, v Value) (context.Context, context.CancelFunc) {
+	d, _ := v.Delete(ctx, 0)
+	return withTimeout(ctx, d)
+}
+
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
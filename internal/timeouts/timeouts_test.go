@@ -0,0 +1,53 @@
+package timeouts_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeouts_Unset(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := timeouts.Create(t.Context(), timeouts.Null())
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancel = timeouts.Delete(t.Context(), timeouts.Null())
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestTimeouts_Configured(t *testing.T) {
+	t.Parallel()
+
+	v := timeouts.Value{Object: types.ObjectValueMust(
+		map[string]attr.Type{"create": types.StringType, "update": types.StringType, "delete": types.StringType},
+		map[string]attr.Value{"create": types.StringValue("5m"), "update": types.StringNull(), "delete": types.StringValue("1h")},
+	)}
+	now := time.Now()
+
+	ctx, cancel := timeouts.Create(t.Context(), v)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, now.Add(5*time.Minute), deadline, time.Second)
+
+	ctx, cancel = timeouts.Update(t.Context(), v)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancel = timeouts.Delete(t.Context(), v)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, now.Add(time.Hour), deadline, time.Second)
+}
@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// DurationValidator is a custom validator that checks if a string is a valid, non-negative duration.
+type DurationValidator struct{}
+
+// Description provides a description of the validator.
+func (v DurationValidator) Description(context.Context) string {
+	return `Validates that the value is a duration, for example "30s" or "5m".`
+}
+
+// MarkdownDescription provides a markdown description of the validator.
+func (v DurationValidator) MarkdownDescription(context.Context) string {
+	return "Validates that the value is a duration, for example `30s` or `5m`."
+}
+
+// ValidateString checks that the provided string is a valid duration.
+func (v DurationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if !conv.IsKnown(req.ConfigValue) {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	d, err := time.ParseDuration(value)
+	switch {
+	case err != nil:
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("%q is not a valid duration: %v", value, err),
+		))
+	case d < 0:
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("%q must not be negative", value),
+		))
+	}
+}
@@ -0,0 +1,56 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/conv"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// UniqueAttributeValidator validates that no two objects of a list have the same
+// value in a string attribute, for example the region types of a replicas list.
+type UniqueAttributeValidator struct {
+	// Attribute is the name of the string attribute of the list objects.
+	Attribute string
+}
+
+// Description provides a description of the validator.
+func (v UniqueAttributeValidator) Description(context.Context) string {
+	return fmt.Sprintf("Validates that each %s is listed at most once.", v.Attribute)
+}
+
+// MarkdownDescription provides a markdown description of the validator.
+func (v UniqueAttributeValidator) MarkdownDescription(context.Context) string {
+	return fmt.Sprintf("Validates that each `%s` is listed at most once.", v.Attribute)
+}
+
+// ValidateList checks that the attribute values of the list objects are unique.
+func (v UniqueAttributeValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if !conv.IsKnown(req.ConfigValue) {
+		return
+	}
+
+	seen := map[string]int{}
+	for i, elem := range req.ConfigValue.Elements() {
+		obj, ok := elem.(basetypes.ObjectValue)
+		if !ok {
+			continue
+		}
+		val, ok := obj.Attributes()[v.Attribute].(basetypes.StringValue)
+		if !ok || !conv.IsKnown(val) {
+			continue
+		}
+
+		if j, dup := seen[val.ValueString()]; dup {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i).AtName(v.Attribute),
+				"Duplicate Value",
+				fmt.Sprintf("%s %q is already listed at index %d.", v.Attribute, val.ValueString(), j),
+			)
+			continue
+		}
+		seen[val.ValueString()] = i
+	}
+}
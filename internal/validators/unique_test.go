@@ -0,0 +1,40 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniqueAttributeValidator(t *testing.T) {
+	t.Parallel()
+
+	objType := types.ObjectType{AttrTypes: map[string]attr.Type{"region_type": types.StringType}}
+	elem := func(v types.String) attr.Value {
+		return types.ObjectValueMust(objType.AttrTypes, map[string]attr.Value{"region_type": v})
+	}
+	list := types.ListValueMust(objType, []attr.Value{
+		elem(types.StringValue("baremetal")),
+		elem(types.StringValue("cloud")),
+		elem(types.StringUnknown()),
+		elem(types.StringUnknown()),
+		elem(types.StringValue("baremetal")),
+	})
+
+	req := validator.ListRequest{Path: path.Root("replicas"), ConfigValue: list}
+	resp := &validator.ListResponse{}
+	validators.UniqueAttributeValidator{Attribute: "region_type"}.ValidateList(t.Context(), req, resp)
+
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, "Duplicate Value", resp.Diagnostics[0].Summary())
+	assert.Equal(t, `region_type "baremetal" is already listed at index 0.`, resp.Diagnostics[0].Detail())
+	pd, ok := resp.Diagnostics[0].(interface{ Path() path.Path })
+	require.True(t, ok)
+	assert.Equal(t, path.Root("replicas").AtListIndex(4).AtName("region_type"), pd.Path())
+}
@@ -0,0 +1,141 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/gamefabric/gf-apiclient/tools/clientset"
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	v1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	"github.com/gamefabric/gf-apicore/runtime"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultTimeout bounds a wait if the context has no deadline.
+const DefaultTimeout = 24 * time.Hour
+
+// Condition reports whether an object reached the awaited state.
+// A returned error ends the wait.
+type Condition[T runtime.Object] func(obj T) (done bool, err error)
+
+type options struct {
+	awaited          string
+	pending          string
+	untilNotFound    bool
+	progressInterval time.Duration
+}
+
+// Option configures a wait.
+type Option func(*options)
+
+// Awaiting sets the description of the awaited state, for example "rollout".
+// It is used in progress logs and errors.
+func Awaiting(state string) Option {
+	return func(o *options) {
+		o.awaited = state
+	}
+}
+
+// WithProgressInterval sets the interval between progress logs. Defaults to 30 seconds.
+func WithProgressInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.progressInterval = d
+	}
+}
+
+// untilNotFound ends the wait successfully once the object is not found.
+func untilNotFound() Option {
+	return func(o *options) {
+		o.untilNotFound = true
+		o.pending = "still present"
+	}
+}
+
+// PollUntil polls the object until cond is met.
+//
+// The wait is bounded by the deadline of ctx, or DefaultTimeout if it has none. Transient
+// errors getting the object are retried with jittered exponential backoff, while terminal
+// errors such as a missing object or denied access end the wait. If cond is not met when
+// the wait ends, a *TimeoutError with the last observed state of the object is returned.
+func PollUntil[T runtime.Object](ctx context.Context, getFn clientset.Getter[T], name string, cond Condition[T], opts ...Option) error {
+	o := options{
+		awaited:          "condition",
+		pending:          "condition not met",
+		progressInterval: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 500 * time.Millisecond
+	bo.MaxInterval = 30 * time.Second
+	bo.RandomizationFactor = 0.5
+
+	errPending := errors.New(o.pending)
+	start, lastLog := time.Now(), time.Now()
+	retryOpts := []backoff.RetryOption{
+		backoff.WithBackOff(bo),
+		backoff.WithMaxElapsedTime(0), // The context deadline ends the wait.
+		backoff.WithNotify(func(err error, next time.Duration) {
+			if time.Since(lastLog) < o.progressInterval {
+				return
+			}
+			lastLog = time.Now()
+			tflog.Info(ctx, "Waiting for "+o.awaited, map[string]any{
+				"name":    name,
+				"elapsed": time.Since(start).Round(time.Second).String(),
+				"reason":  err.Error(),
+			})
+		}),
+	}
+
+	var (
+		last  T
+		found bool
+	)
+	_, err := backoff.Retry(ctx, func() (struct{}, error) {
+		obj, err := getFn.Get(ctx, name, v1.GetOptions{})
+		switch {
+		case err == nil:
+		case o.untilNotFound && apierrors.IsNotFound(err):
+			return struct{}{}, nil
+		case isTerminal(err):
+			return struct{}{}, backoff.Permanent(err)
+		default:
+			return struct{}{}, err
+		}
+		last, found = obj, true
+
+		done, err := cond(obj)
+		switch {
+		case err != nil:
+			return struct{}{}, backoff.Permanent(fmt.Errorf("checking %s of %q: %w", o.awaited, name, err))
+		case !done:
+			return struct{}{}, errPending
+		}
+		return struct{}{}, nil
+	}, retryOpts...)
+	if err != nil && found && (errors.Is(err, errPending) || ctx.Err() != nil) {
+		return &TimeoutError{Name: name, Awaited: o.awaited, Reason: o.pending, LastObserved: describe(last), Err: err}
+	}
+	return err
+}
+
+// isTerminal reports whether err getting an object will not resolve by retrying.
+func isTerminal(err error) bool {
+	return apierrors.IsNotFound(err) ||
+		apierrors.IsForbidden(err) ||
+		apierrors.IsUnauthorized(err) ||
+		apierrors.IsBadRequest(err) ||
+		apierrors.IsInvalid(err)
+}
@@ -0,0 +1,166 @@
+package wait_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	apierrors "github.com/gamefabric/gf-apicore/api/errors"
+	metav1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
+	v1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/gf-core/pkg/apiclient/fake"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollUntil(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		cs, err := fake.New(&v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-env",
+			},
+		})
+		require.NoError(t, err)
+
+		now := time.Now()
+		calls := 0
+
+		err = wait.PollUntil(t.Context(), cs.CoreV1().Environments(), "test-env", func(*v1.Environment) (bool, error) {
+			calls++
+			return time.Since(now) >= time.Minute, nil
+		})
+
+		require.NoError(t, err)
+		assert.Greater(t, calls, 1)
+		assert.GreaterOrEqual(t, time.Since(now), time.Minute)
+	})
+}
+
+func TestPollUntil_Timeout(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		cs, err := fake.New(&v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-env",
+			},
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Minute)
+		defer cancel()
+
+		now := time.Now()
+
+		err = wait.PollUntil(ctx, cs.CoreV1().Environments(), "test-env", func(*v1.Environment) (bool, error) {
+			return false, nil
+		}, wait.Awaiting("readiness"))
+
+		var timeoutErr *wait.TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "readiness", timeoutErr.Awaited)
+		assert.NotEmpty(t, timeoutErr.LastObserved)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 5*time.Minute, time.Since(now))
+	})
+}
+
+func TestPollUntil_DefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		cs, err := fake.New(&v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-env",
+			},
+		})
+		require.NoError(t, err)
+
+		now := time.Now()
+
+		err = wait.PollUntil(t.Context(), cs.CoreV1().Environments(), "test-env", func(*v1.Environment) (bool, error) {
+			return false, nil
+		})
+
+		var timeoutErr *wait.TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, wait.DefaultTimeout, time.Since(now))
+	})
+}
+
+func TestPollUntil_ConditionError(t *testing.T) {
+	t.Parallel()
+
+	cs, err := fake.New(&v1.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-env",
+		},
+	})
+	require.NoError(t, err)
+
+	wantErr := errors.New("test")
+
+	err = wait.PollUntil(t.Context(), cs.CoreV1().Environments(), "test-env", func(*v1.Environment) (bool, error) {
+		return false, wantErr
+	})
+
+	require.ErrorIs(t, err, wantErr)
+	var timeoutErr *wait.TimeoutError
+	assert.NotErrorAs(t, err, &timeoutErr)
+}
+
+func TestPollUntil_TerminalError(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		cs, err := fake.New()
+		require.NoError(t, err)
+
+		now := time.Now()
+
+		err = wait.PollUntil(t.Context(), cs.CoreV1().Environments(), "non-existent", func(*v1.Environment) (bool, error) {
+			return true, nil
+		})
+
+		require.Error(t, err)
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.Equal(t, time.Duration(0), time.Since(now))
+	})
+}
+
+func TestPollUntil_LogsProgress(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		cs, err := fake.New(&v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-env",
+			},
+		})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		ctx := tflogtest.RootLogger(t.Context(), &buf)
+		now := time.Now()
+
+		err = wait.PollUntil(ctx, cs.CoreV1().Environments(), "test-env", func(*v1.Environment) (bool, error) {
+			return time.Since(now) >= 2*time.Minute, nil
+		}, wait.Awaiting("readiness"), wait.WithProgressInterval(time.Minute))
+		require.NoError(t, err)
+
+		entries, err := tflogtest.MultilineJSONDecode(&buf)
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		assert.LessOrEqual(t, len(entries), 2)
+		assert.Equal(t, "Waiting for readiness", entries[0]["@message"])
+		assert.Equal(t, "test-env", entries[0]["name"])
+		assert.Equal(t, "condition not met", entries[0]["reason"])
+	})
+}
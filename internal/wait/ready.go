@@ -0,0 +1,29 @@
+package wait
+
+import (
+	"github.com/gamefabric/gf-apicore/runtime"
+)
+
+// ReadyStatus is the readiness state of an object.
+type ReadyStatus struct {
+	// Generation is the generation of the object's desired state.
+	Generation int64
+	// ObservedGeneration is the generation most recently acted on by the controller.
+	ObservedGeneration int64
+	// Ready reports whether the object reached its desired state.
+	Ready bool
+}
+
+// Ready returns a condition that is met once the controller acted on the latest
+// generation of the object and reports it as ready.
+func Ready[T runtime.Object](status func(T) ReadyStatus) Condition[T] {
+	return func(obj T) (bool, error) {
+		st := status(obj)
+		return observed(st.Generation, st.ObservedGeneration) && st.Ready, nil
+	}
+}
+
+// observed reports whether the controller acted on the latest generation of an object.
+func observed(generation, observedGeneration int64) bool {
+	return observedGeneration >= generation
+}
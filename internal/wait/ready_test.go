@@ -0,0 +1,49 @@
+package wait_test
+
+import (
+	"testing"
+
+	v1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status wait.ReadyStatus
+		want   bool
+	}{
+		{
+			name:   "ready",
+			status: wait.ReadyStatus{Generation: 2, ObservedGeneration: 2, Ready: true},
+			want:   true,
+		},
+		{
+			name:   "generation not observed",
+			status: wait.ReadyStatus{Generation: 3, ObservedGeneration: 2, Ready: true},
+		},
+		{
+			name:   "not ready",
+			status: wait.ReadyStatus{Generation: 2, ObservedGeneration: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cond := wait.Ready(func(*v1.Environment) wait.ReadyStatus {
+				return test.status
+			})
+
+			got, err := cond(&v1.Environment{})
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package wait
+
+import (
+	"github.com/gamefabric/gf-apicore/runtime"
+)
+
+// RolloutStatus is the rollout state of an object.
+type RolloutStatus struct {
+	// Generation is the generation of the object's desired state.
+	Generation int64
+	// ObservedGeneration is the generation most recently acted on by the controller.
+	ObservedGeneration int64
+	// ReadyReplicas is the number of ready replicas by group, for example by region type.
+	ReadyReplicas map[string]int32
+}
+
+// RolledOut returns a condition that is met once the controller acted on the latest
+// generation of the object and at least minReady replicas are ready in each group.
+func RolledOut[T runtime.Object](status func(T) RolloutStatus, minReady map[string]int32) Condition[T] {
+	return func(obj T) (bool, error) {
+		st := status(obj)
+		if !observed(st.Generation, st.ObservedGeneration) {
+			return false, nil
+		}
+		for group, want := range minReady {
+			if st.ReadyReplicas[group] < want {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,69 @@
+package wait_test
+
+import (
+	"testing"
+
+	v1 "github.com/gamefabric/gf-core/pkg/api/core/v1"
+	"github.com/gamefabric/terraform-provider-gamefabric/internal/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolledOut(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status wait.RolloutStatus
+		want   bool
+	}{
+		{
+			name: "rolled out",
+			status: wait.RolloutStatus{
+				Generation:         2,
+				ObservedGeneration: 2,
+				ReadyReplicas:      map[string]int32{"baremetal": 2, "cloud": 1},
+			},
+			want: true,
+		},
+		{
+			name: "generation not observed",
+			status: wait.RolloutStatus{
+				Generation:         3,
+				ObservedGeneration: 2,
+				ReadyReplicas:      map[string]int32{"baremetal": 2, "cloud": 1},
+			},
+		},
+		{
+			name: "not enough ready replicas",
+			status: wait.RolloutStatus{
+				Generation:         2,
+				ObservedGeneration: 2,
+				ReadyReplicas:      map[string]int32{"baremetal": 1, "cloud": 1},
+			},
+		},
+		{
+			name: "missing group",
+			status: wait.RolloutStatus{
+				Generation:         2,
+				ObservedGeneration: 2,
+				ReadyReplicas:      map[string]int32{"baremetal": 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cond := wait.RolledOut(func(*v1.Environment) wait.RolloutStatus {
+				return test.status
+			}, map[string]int32{"baremetal": 2, "cloud": 1})
+
+			got, err := cond(&v1.Environment{})
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
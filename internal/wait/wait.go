@@ -2,34 +2,85 @@ package wait
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"time"
 
-	"github.com/cenkalti/backoff/v5"
 	"github.com/gamefabric/gf-apiclient/tools/clientset"
-	apierrors "github.com/gamefabric/gf-apicore/api/errors"
-	v1 "github.com/gamefabric/gf-apicore/apis/meta/v1"
 	"github.com/gamefabric/gf-apicore/runtime"
 )
 
+// TimeoutError is returned when a wait ends before the object reached the awaited state.
+type TimeoutError struct {
+	// Name is the name of the object.
+	Name string
+	// Awaited describes the awaited state, for example "deletion".
+	Awaited string
+	// Reason describes why the awaited state was not reached, for example "still present".
+	Reason string
+	// LastObserved describes the last observed state of the object,
+	// including its status conditions.
+	LastObserved string
+	// Err is the error that ended the wait.
+	Err error
+}
+
+// Error returns the error message.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s of %q did not complete: %s (%v); last observed state: %s", e.Awaited, e.Name, e.Reason, e.Err, e.LastObserved)
+}
+
+// Unwrap returns the error that ended the wait.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
 // PollUntilNotFound polls until the resource is not found.
+//
+// The wait is bounded by the deadline of ctx, or DefaultTimeout if it has none. If the
+// object is still present when the wait ends, a *TimeoutError with its last observed
+// state is returned.
 func PollUntilNotFound[T runtime.Object](ctx context.Context, getFn clientset.Getter[T], name string) error {
-	bo := backoff.NewExponentialBackOff()
-	opts := []backoff.RetryOption{
-		backoff.WithBackOff(bo),
-		backoff.WithMaxElapsedTime(24 * time.Hour), // README: Poll forever until the object is gone. We can revisit this decision if necessary.
+	return PollUntil(ctx, getFn, name, func(T) (bool, error) {
+		return false, nil
+	}, Awaiting("deletion"), untilNotFound())
+}
+
+// describe returns a short description of the state of obj,
+// consisting of its deletion timestamp, finalizers and status.
+func describe(obj runtime.Object) string {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "unknown"
+	}
+	var raw struct {
+		Metadata struct {
+			DeletionTimestamp json.RawMessage `json:"deletionTimestamp,omitempty"`
+			Finalizers        json.RawMessage `json:"finalizers,omitempty"`
+		} `json:"metadata"`
+		Status json.RawMessage `json:"status,omitempty"`
+	}
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return "unknown"
+	}
+	state := struct {
+		DeletionTimestamp json.RawMessage `json:"deletionTimestamp,omitempty"`
+		Finalizers        json.RawMessage `json:"finalizers,omitempty"`
+		Status            json.RawMessage `json:"status,omitempty"`
+	}{
+		DeletionTimestamp: nullToEmpty(raw.Metadata.DeletionTimestamp),
+		Finalizers:        nullToEmpty(raw.Metadata.Finalizers),
+		Status:            nullToEmpty(raw.Status),
 	}
+	b, err = json.Marshal(state)
+	if err != nil {
+		return "unknown"
+	}
+	return string(b)
+}
 
-	_, err := backoff.Retry(ctx, func() (struct{}, error) {
-		_, err := getFn.Get(ctx, name, v1.GetOptions{})
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				return struct{}{}, nil
-			}
-			return struct{}{}, err
-		}
-		return struct{}{}, fmt.Errorf("%q still present", name)
-	}, opts...)
-
-	return err
+func nullToEmpty(v json.RawMessage) json.RawMessage {
+	if string(v) == "null" || string(v) == "{}" {
+		return nil
+	}
+	return v
 }
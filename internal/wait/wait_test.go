@@ -1,6 +1,7 @@
 package wait_test
 
 import (
+	"context"
 	"testing"
 	"testing/synctest"
 	"time"
@@ -72,3 +73,30 @@ func TestPollUntilNotFound_GoneLater(t *testing.T) {
 		assert.GreaterOrEqual(t, time.Since(now), time.Minute)
 	})
 }
+
+func TestPollUntilNotFound_Timeout(t *testing.T) {
+	t.Parallel()
+
+	synctest.Test(t, func(t *testing.T) {
+		cs, err := fake.New(&v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-env",
+			},
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Minute)
+		defer cancel()
+
+		now := time.Now()
+
+		err = wait.PollUntilNotFound(ctx, cs.CoreV1().Environments(), "test-env")
+
+		var timeoutErr *wait.TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, "test-env", timeoutErr.Name)
+		assert.NotEmpty(t, timeoutErr.LastObserved)
+		assert.Equal(t, 5*time.Minute, time.Since(now))
+	})
+}